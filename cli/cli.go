@@ -0,0 +1,192 @@
+//========================================================================
+// cli.go
+//========================================================================
+// Commands for working with board files from a terminal, without opening
+// the editor
+//
+// Author: Aidan McNay
+// Date: June 22nd, 2024
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"jeopardy/file"
+	"jeopardy/logic"
+	"os"
+	"strings"
+)
+
+//------------------------------------------------------------------------
+// Define a Command
+//------------------------------------------------------------------------
+// Commands report problems by returning an error (which is printed), and
+// may return errSilent once they've already printed their own
+
+type command struct {
+	Name  string
+	Usage string
+	Help  string
+	Run   func(args []string, stdout, stderr io.Writer) error
+}
+
+var errSilent = errors.New("")
+
+var commands [](*command)
+
+func init() {
+	commands = [](*command){
+		validateCommand,
+		infoCommand,
+		convertCommand,
+		exportCommand,
+		helpCommand,
+	}
+}
+
+func findCommand(name string) *command {
+	for _, v := range commands {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------
+// Handles
+//------------------------------------------------------------------------
+// Whether the program's arguments are meant for the command line, rather
+// than opening the editor. macOS passes a "-psn" argument to apps it
+// launches, which shouldn't count
+
+func Handles(args []string) bool {
+	return len(args) > 0 && !strings.HasPrefix(args[0], "-psn")
+}
+
+//------------------------------------------------------------------------
+// Run
+//------------------------------------------------------------------------
+// Runs the command named by the first argument, returning the exit code
+
+func Run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	name := args[0]
+	if name == "-h" || name == "-help" || name == "--help" {
+		name = "help"
+	}
+	cmd := findCommand(name)
+	if cmd == nil {
+		fmt.Fprintf(stderr, "jeopardy: unknown command %q\n\n", args[0])
+		usage(stderr)
+		return 2
+	}
+
+	err := cmd.Run(args[1:], stdout, stderr)
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case err == errSilent:
+		return 1
+	default:
+		fmt.Fprintf(stderr, "jeopardy %v: %v\n", cmd.Name, err)
+		return 1
+	}
+}
+
+//------------------------------------------------------------------------
+// usage
+//------------------------------------------------------------------------
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: jeopardy [command] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "With no command, the editor is opened.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, v := range commands {
+		fmt.Fprintf(w, "  %-40v %v\n", v.Name+" "+v.Usage, v.Help)
+	}
+}
+
+var helpCommand = &command{
+	Name:  "help",
+	Usage: "[COMMAND]",
+	Help:  "Describe a command's options",
+	Run: func(args []string, stdout, stderr io.Writer) error {
+		if len(args) == 0 {
+			usage(stdout)
+			return nil
+		}
+		cmd := findCommand(args[0])
+		if cmd == nil || cmd.Name == "help" {
+			usage(stdout)
+			return nil
+		}
+		// Every command prints its usage when asked with -h
+		cmd.Run([]string{"-h"}, stdout, stdout)
+		return nil
+	},
+}
+
+//------------------------------------------------------------------------
+// Helper Functions
+//------------------------------------------------------------------------
+
+// Flags print their own errors and usage, so they're silent otherwise
+
+func newFlags(cmd *command, w io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	flags.SetOutput(w)
+	flags.Usage = func() {
+		fmt.Fprintf(w, "Usage: jeopardy %v %v\n\n%v\n", cmd.Name, cmd.Usage,
+			cmd.Help)
+		hasFlags := false
+		flags.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintln(w)
+			flags.PrintDefaults()
+		}
+	}
+	return flags
+}
+
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errSilent
+	}
+	return nil
+}
+
+// Loads a board, along with the version of the format it was saved in
+
+func loadBoard(path string) (*logic.Board, int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	version, err := file.Version(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	var board *logic.Board = nil
+	if err := file.Load(io.NopCloser(bytes.NewReader(data)), &board); err != nil {
+		return nil, 0, err
+	}
+	if board == nil {
+		return nil, 0, errors.New("file doesn't contain a board")
+	}
+	return board, version, nil
+}
@@ -0,0 +1,192 @@
+//========================================================================
+// cli_test.go
+//========================================================================
+// Tests that commands give the right output and exit codes, which scripts
+// (such as pre-commit hooks) rely on
+//
+// Author: Aidan McNay
+// Date: June 22nd, 2024
+
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"jeopardy/cli"
+	"jeopardy/file"
+	"jeopardy/logic"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//------------------------------------------------------------------------
+// Helper Functions
+//------------------------------------------------------------------------
+
+func run(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := cli.Run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func expectCode(t *testing.T, args []string, want int) string {
+	t.Helper()
+	code, stdout, stderr := run(args...)
+	if code != want {
+		t.Errorf("%v: exited with %v, want %v\nstdout: %v\nstderr: %v",
+			args, code, want, stdout, stderr)
+	}
+	return stdout
+}
+
+func saveBoard(t *testing.T, board *logic.Board) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "board.jpdy")
+	if err := file.SaveFile(path, board, false); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func goodBoard() *logic.Board {
+	board := logic.MakeBoard("Trivia Night")
+	history := logic.MakeCategory("History")
+	history.AddQuestions(
+		logic.MakeQuestion("The year the Berlin Wall fell", "What is 1989?", 200),
+	)
+	science := logic.MakeCategory("Science")
+	science.AddQuestions(
+		logic.MakeQuestion("H2O, more commonly", "What is water?", 200),
+	)
+	board.Rounds[0].AddCategories(history, science)
+	board.AddPlayers(logic.MakePlayer("Zed"))
+	return board
+}
+
+//------------------------------------------------------------------------
+// Exit Codes
+//------------------------------------------------------------------------
+// 0 for success, 1 for a failed command and 2 for a command that doesn't
+// exist
+
+func TestExitCodes(t *testing.T) {
+	expectCode(t, []string{}, 2)
+	expectCode(t, []string{"frobnicate"}, 2)
+	expectCode(t, []string{"help"}, 0)
+	expectCode(t, []string{"validate", "-h"}, 0)
+	expectCode(t, []string{"validate"}, 1)
+	expectCode(t, []string{"validate", "-bogus", "board.jpdy"}, 1)
+	expectCode(t, []string{"info", filepath.Join(t.TempDir(), "missing.jpdy")}, 1)
+}
+
+//------------------------------------------------------------------------
+// validate
+//------------------------------------------------------------------------
+
+func TestValidateGood(t *testing.T) {
+	path := saveBoard(t, goodBoard())
+	stdout := expectCode(t, []string{"validate", path}, 0)
+	if !strings.Contains(stdout, path+": ok") {
+		t.Errorf("expected %v to be ok, got %q", path, stdout)
+	}
+	expectCode(t, []string{"validate", "-strict", path}, 0)
+}
+
+func TestValidateErrors(t *testing.T) {
+	board := goodBoard()
+	board.Rounds[0].Categories[0].Questions[0].Answer = ""
+	path := saveBoard(t, board)
+	stdout := expectCode(t, []string{"validate", path}, 1)
+	if !strings.Contains(stdout, "error: ") {
+		t.Errorf("expected an error to be reported, got %q", stdout)
+	}
+}
+
+func TestValidateWarnings(t *testing.T) {
+	board := goodBoard()
+	board.Rounds[0].Categories[1].Name = "History"
+	path := saveBoard(t, board)
+	stdout := expectCode(t, []string{"validate", path}, 0)
+	if !strings.Contains(stdout, "warning: ") {
+		t.Errorf("expected a warning to be reported, got %q", stdout)
+	}
+	expectCode(t, []string{"validate", "-strict", path}, 1)
+}
+
+func TestValidateUnreadable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "garbage.jpdy")
+	if err := os.WriteFile(path, []byte("not a board"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expectCode(t, []string{"validate", saveBoard(t, goodBoard()), path}, 1)
+}
+
+//------------------------------------------------------------------------
+// convert
+//------------------------------------------------------------------------
+
+func TestConvertVersion1(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "file", "testdata", "v1.jpdy"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "v1.jpdy")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "converted.jpdy")
+	expectCode(t, []string{"convert", "-o", out, path}, 0)
+
+	converted, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var header struct {
+		Format  string
+		Version int
+	}
+	if err := json.Unmarshal(converted, &header); err != nil {
+		t.Fatal(err)
+	}
+	if header.Format != file.Format || header.Version != file.CurrentVersion {
+		t.Errorf("converted header is %+v, want format %v version %v",
+			header, file.Format, file.CurrentVersion)
+	}
+	stdout := expectCode(t, []string{"info", out}, 0)
+	if !strings.Contains(stdout, "Science Night") {
+		t.Errorf("expected the converted board's name, got %q", stdout)
+	}
+}
+
+//------------------------------------------------------------------------
+// export
+//------------------------------------------------------------------------
+
+func TestExportOptions(t *testing.T) {
+	path := saveBoard(t, goodBoard())
+
+	// Options for other formats are accepted, and ignored
+	stdout := expectCode(t,
+		[]string{"export", "-format", "csv", "-players=false", path}, 0)
+	if !strings.HasPrefix(stdout, "Round,Category,Points") {
+		t.Errorf("expected a CSV header, got %q", stdout)
+	}
+	if !strings.Contains(stdout, "What is water?") {
+		t.Errorf("expected the questions to be exported, got %q", stdout)
+	}
+
+	stdout = expectCode(t, []string{"export", "-format", "sheet", path}, 0)
+	if !strings.Contains(stdout, "Zed") {
+		t.Error("expected the answer sheet to include the players")
+	}
+	stdout = expectCode(t,
+		[]string{"export", "-format", "sheet", "-players=false", path}, 0)
+	if strings.Contains(stdout, "Zed") {
+		t.Error("expected -players=false to leave out the players")
+	}
+
+	expectCode(t, []string{"export", "-format", "pdf", path}, 1)
+}
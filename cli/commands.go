@@ -0,0 +1,274 @@
+//========================================================================
+// commands.go
+//========================================================================
+// The individual commands available from the command line
+//
+// Author: Aidan McNay
+// Date: June 22nd, 2024
+
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"jeopardy/file"
+	"jeopardy/logic"
+	"jeopardy/transfer"
+	"os"
+	"strings"
+)
+
+//------------------------------------------------------------------------
+// validate
+//------------------------------------------------------------------------
+// Checks each board for problems, failing if any can't be loaded or have
+// errors (or warnings, if strict)
+
+var validateCommand = &command{
+	Name:  "validate",
+	Usage: "[-strict] FILE...",
+	Help:  "Check boards for problems",
+}
+
+func init() {
+	validateCommand.Run = func(args []string, stdout, stderr io.Writer) error {
+		flags := newFlags(validateCommand, stderr)
+		strict := flags.Bool("strict", false, "fail on warnings as well as errors")
+		if err := parseFlags(flags, args); err != nil {
+			return err
+		}
+		if flags.NArg() == 0 {
+			flags.Usage()
+			return errSilent
+		}
+
+		failed := false
+		for _, path := range flags.Args() {
+			board, _, err := loadBoard(path)
+			if err != nil {
+				fmt.Fprintf(stdout, "%v: %v\n", path, err)
+				failed = true
+				continue
+			}
+
+			problems := board.Validate()
+			for _, problem := range problems {
+				fmt.Fprintf(stdout, "%v: %v\n", path, problem)
+			}
+			numErrors, numWarnings := logic.CountProblems(problems)
+			if numErrors > 0 || (*strict && numWarnings > 0) {
+				failed = true
+			}
+			if len(problems) == 0 {
+				fmt.Fprintf(stdout, "%v: ok\n", path)
+			}
+		}
+		if failed {
+			return errSilent
+		}
+		return nil
+	}
+}
+
+//------------------------------------------------------------------------
+// info
+//------------------------------------------------------------------------
+// Summarizes what's on each board
+
+var infoCommand = &command{
+	Name:  "info",
+	Usage: "FILE...",
+	Help:  "Summarize boards",
+}
+
+func init() {
+	infoCommand.Run = func(args []string, stdout, stderr io.Writer) error {
+		flags := newFlags(infoCommand, stderr)
+		if err := parseFlags(flags, args); err != nil {
+			return err
+		}
+		if flags.NArg() == 0 {
+			flags.Usage()
+			return errSilent
+		}
+
+		for i, path := range flags.Args() {
+			if i > 0 {
+				fmt.Fprintln(stdout)
+			}
+			board, version, err := loadBoard(path)
+			if err != nil {
+				return fmt.Errorf("%v: %w", path, err)
+			}
+
+			fmt.Fprintf(stdout, "File:    %v (format version %v", path, version)
+			if version < file.CurrentVersion {
+				fmt.Fprintf(stdout, ", current is %v", file.CurrentVersion)
+			}
+			fmt.Fprintln(stdout, ")")
+			fmt.Fprintf(stdout, "Name:    %v\n", board.Name)
+
+			fmt.Fprintf(stdout, "Rounds:  %v\n", len(board.Rounds))
+			for _, round := range board.Rounds {
+				questions := round.Questions()
+				dailyDoubles := 0
+				for _, q := range questions {
+					if q.DailyDouble {
+						dailyDoubles++
+					}
+				}
+				fmt.Fprintf(stdout,
+					"  %v: %v categories, %v questions, %v Daily Doubles, up to $%v\n",
+					round.Name, len(round.Categories), len(questions),
+					dailyDoubles, round.MaxValue())
+			}
+
+			if board.FinalQuestion() != nil {
+				fmt.Fprintf(stdout, "Final:   %v\n", board.Final.Name)
+			} else {
+				fmt.Fprintln(stdout, "Final:   (none)")
+			}
+
+			var players []string = nil
+			for _, p := range board.Players {
+				players = append(players,
+					fmt.Sprintf("%v (%v)", p.GetName(), p.GetScore()))
+			}
+			if len(players) == 0 {
+				players = append(players, "(none)")
+			}
+			fmt.Fprintf(stdout, "Players: %v\n", strings.Join(players, ", "))
+		}
+		return nil
+	}
+}
+
+//------------------------------------------------------------------------
+// convert
+//------------------------------------------------------------------------
+// Upgrades a board to the current version of the format, either in place
+// or into a new file
+
+var convertCommand = &command{
+	Name:  "convert",
+	Usage: "[-o OUT] [-backup] FILE",
+	Help:  "Upgrade a board to the current file format",
+}
+
+func init() {
+	convertCommand.Run = func(args []string, stdout, stderr io.Writer) error {
+		flags := newFlags(convertCommand, stderr)
+		out := flags.String("o", "", "where to save the board (defaults to FILE)")
+		keepBackup := flags.Bool("backup", false,
+			"keep the previous version of the output as a .bak file")
+		if err := parseFlags(flags, args); err != nil {
+			return err
+		}
+		if flags.NArg() != 1 {
+			flags.Usage()
+			return errSilent
+		}
+
+		path := flags.Arg(0)
+		board, _, err := loadBoard(path)
+		if err != nil {
+			return fmt.Errorf("%v: %w", path, err)
+		}
+		if *out == "" {
+			*out = path
+		}
+		return file.SaveFile(*out, board, *keepBackup)
+	}
+}
+
+//------------------------------------------------------------------------
+// export
+//------------------------------------------------------------------------
+// Writes a board in another format, to standard output by default
+
+var exportCommand = &command{
+	Name:  "export",
+	Usage: "[-format FORMAT] [-o OUT] [OPTIONS] FILE",
+	Help:  "Export a board to another format",
+}
+
+func exportFormats() string {
+	var names []string = nil
+	for _, v := range transfer.Exporters {
+		names = append(names, v.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Each format's options can be turned on or off with a flag of the same
+// name. Formats may share an option, in which case there's only one flag
+// for it (described by the first format to have it)
+
+func exportOptions(flags *flag.FlagSet) map[string]*bool {
+	var names []string = nil
+	first := map[string]transfer.Option{}
+	formats := map[string][]string{}
+	for _, exporter := range transfer.Exporters {
+		for _, v := range exporter.Options {
+			if _, ok := first[v.Name]; !ok {
+				names = append(names, v.Name)
+				first[v.Name] = v
+			}
+			formats[v.Name] = append(formats[v.Name], exporter.Name)
+		}
+	}
+
+	options := map[string]*bool{}
+	for _, name := range names {
+		usage := fmt.Sprintf("%v (%v only)", first[name].Description,
+			strings.Join(formats[name], ", "))
+		options[name] = flags.Bool(name, first[name].Default, usage)
+	}
+	return options
+}
+
+func init() {
+	exportCommand.Run = func(args []string, stdout, stderr io.Writer) error {
+		flags := newFlags(exportCommand, stderr)
+		format := flags.String("format", transfer.Exporters[0].Name,
+			"the format to export ("+exportFormats()+")")
+		out := flags.String("o", "-", "where to write the export (- for standard output)")
+		optionFlags := exportOptions(flags)
+		if err := parseFlags(flags, args); err != nil {
+			return err
+		}
+		if flags.NArg() != 1 {
+			flags.Usage()
+			return errSilent
+		}
+
+		exporter, err := transfer.FindExporter(*format)
+		if err != nil {
+			return err
+		}
+		// Options that weren't given keep the format's own default
+		options := exporter.Defaults()
+		flags.Visit(func(f *flag.Flag) {
+			if _, ok := options[f.Name]; ok {
+				options[f.Name] = *optionFlags[f.Name]
+			}
+		})
+
+		path := flags.Arg(0)
+		board, _, err := loadBoard(path)
+		if err != nil {
+			return fmt.Errorf("%v: %w", path, err)
+		}
+
+		if *out == "-" {
+			return exporter.Write(stdout, board, options)
+		}
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		return errors.Join(exporter.Write(f, board, options), f.Close())
+	}
+}
@@ -0,0 +1,109 @@
+//========================================================================
+// atomic.go
+//========================================================================
+// Saving local files so that a failed save never damages the original
+//
+// Author: Aidan McNay
+// Date: June 21st, 2024
+
+package file
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+//------------------------------------------------------------------------
+// BackupPath
+//------------------------------------------------------------------------
+// Where the previous version of a file is kept, if backups are enabled
+
+func BackupPath(path string) string {
+	return path + ".bak"
+}
+
+//------------------------------------------------------------------------
+// writeAtomic
+//------------------------------------------------------------------------
+// Writes everything from r to a temporary file next to path, and only
+// renames it into place once it's been completely written to disk. Any
+// existing file keeps its permissions
+
+func writeAtomic(path string, r io.Reader) (err error) {
+	var mode fs.FileMode = 0644
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, "."+name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = io.Copy(tmp, r); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+//------------------------------------------------------------------------
+// backup
+//------------------------------------------------------------------------
+// Copies the current version of a file to its backup, replacing any older
+// backup. Nothing needs backing up if the file doesn't exist yet
+
+func backup(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeAtomic(BackupPath(path), f)
+}
+
+//------------------------------------------------------------------------
+// SaveFile
+//------------------------------------------------------------------------
+// Saves v to the local file at path without ever leaving it partially
+// written, optionally keeping the previous version as a backup
+
+func SaveFile(path string, v interface{}, keepBackup bool) error {
+	file_lock.Lock()
+	defer file_lock.Unlock()
+
+	r, err := marshal(v)
+	if err != nil {
+		return err
+	}
+	if keepBackup {
+		if err := backup(path); err != nil {
+			return err
+		}
+	}
+	return writeAtomic(path, r)
+}
@@ -0,0 +1,133 @@
+//========================================================================
+// atomic_test.go
+//========================================================================
+// Tests that saving a file keeps its permissions and backup, and never
+// leaves anything behind when it fails
+//
+// Author: Aidan McNay
+// Date: June 21st, 2024
+
+package file_test
+
+import (
+	"errors"
+	"io/fs"
+	"jeopardy/file"
+	"jeopardy/logic"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//------------------------------------------------------------------------
+// Helper Functions
+//------------------------------------------------------------------------
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// Any temporary files left next to the saved file
+
+func leftovers(t *testing.T, dir string) []string {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, ".*.tmp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return matches
+}
+
+//------------------------------------------------------------------------
+// Permissions
+//------------------------------------------------------------------------
+
+func TestSaveKeepsMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "board.jpdy")
+	if err := os.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.SaveFile(path, logic.MakeBoard("New"), false); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, "mode", info.Mode().Perm(), fs.FileMode(0600))
+}
+
+//------------------------------------------------------------------------
+// Backups
+//------------------------------------------------------------------------
+
+func TestSaveBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "board.jpdy")
+
+	// A new file has nothing to back up
+	if err := file.SaveFile(path, logic.MakeBoard("First"), true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(file.BackupPath(path)); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected no backup of a new file, got %v", err)
+	}
+
+	first := readFile(t, path)
+	if err := file.SaveFile(path, logic.MakeBoard("Second"), true); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, "backup", readFile(t, file.BackupPath(path)), first)
+	if !strings.Contains(readFile(t, path), "Second") {
+		t.Error("expected the file to hold the new board")
+	}
+}
+
+func TestSaveWithoutBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "board.jpdy")
+	for _, name := range []string{"First", "Second"} {
+		if err := file.SaveFile(path, logic.MakeBoard(name), false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(file.BackupPath(path)); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected no backup, got %v", err)
+	}
+}
+
+//------------------------------------------------------------------------
+// Failed Saves
+//------------------------------------------------------------------------
+
+func TestFailedSaveLeavesOriginal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "board.jpdy")
+	if err := file.SaveFile(path, logic.MakeBoard("Original"), false); err != nil {
+		t.Fatal(err)
+	}
+	original := readFile(t, path)
+
+	// Channels can't be encoded, so this save fails
+	if err := file.SaveFile(path, make(chan int), true); err == nil {
+		t.Fatal("expected saving a channel to fail")
+	}
+	expect(t, "contents", readFile(t, path), original)
+	expect(t, "leftover files", len(leftovers(t, dir)), 0)
+	if _, err := os.Stat(file.BackupPath(path)); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected no backup from a failed save, got %v", err)
+	}
+}
+
+func TestFailedSaveToMissingDirectory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "missing", "board.jpdy")
+	if err := file.SaveFile(path, logic.MakeBoard("Nowhere"), false); err == nil {
+		t.Fatal("expected saving into a missing directory to fail")
+	}
+	expect(t, "leftover files", len(leftovers(t, dir)), 0)
+}
@@ -13,17 +13,37 @@ package file
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"sync"
 )
 
+//------------------------------------------------------------------------
+// Define the File Header
+//------------------------------------------------------------------------
+// Every file records the version of the format it was saved with, so
+// that files from older versions can be upgraded when they're loaded
+
+const Format = "jeopardy"
+const CurrentVersion = 3
+
+type header struct {
+	Format  string
+	Version int
+	Content json.RawMessage
+}
+
 //------------------------------------------------------------------------
 // Marshalling
 //------------------------------------------------------------------------
 // Functions for translating objects into byte streams
 
 func marshal(v interface{}) (io.Reader, error) {
-	b, err := json.MarshalIndent(v, "", "\t")
+	content, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.MarshalIndent(header{Format, CurrentVersion, content}, "", "\t")
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +51,51 @@ func marshal(v interface{}) (io.Reader, error) {
 }
 
 func unmarshal(r io.Reader, v interface{}) error {
-	return json.NewDecoder(r).Decode(v)
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	content, version, err := parseHeader(data)
+	if err != nil {
+		return err
+	}
+	content, err = Migrate(content, version)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, v)
+}
+
+//------------------------------------------------------------------------
+// parseHeader
+//------------------------------------------------------------------------
+// Splits a file into its content and the version it was saved with.
+// Files from before the header was added are the content by itself
+
+func parseHeader(data []byte) (json.RawMessage, int, error) {
+	var h header
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, 0, err
+	}
+	if h.Format != Format {
+		return data, detectVersion(data), nil
+	}
+	if h.Version > CurrentVersion {
+		return nil, 0, fmt.Errorf(
+			"file is from a newer version of the format (%v, but only up to %v is supported)",
+			h.Version, CurrentVersion)
+	}
+	return h.Content, h.Version, nil
+}
+
+//------------------------------------------------------------------------
+// Version
+//------------------------------------------------------------------------
+// Returns the version of the format that a file was saved with
+
+func Version(data []byte) (int, error) {
+	_, version, err := parseHeader(data)
+	return version, err
 }
 
 //------------------------------------------------------------------------
@@ -0,0 +1,194 @@
+//========================================================================
+// file_test.go
+//========================================================================
+// Tests that boards from each version of the format load correctly
+//
+// Author: Aidan McNay
+// Date: June 20th, 2024
+
+package file_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"jeopardy/file"
+	"jeopardy/logic"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//------------------------------------------------------------------------
+// Helper Functions
+//------------------------------------------------------------------------
+
+func loadFixture(t *testing.T, name string) *logic.Board {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var board *logic.Board
+	if err := file.Load(f, &board); err != nil {
+		t.Fatalf("loading %v: %v", name, err)
+	}
+	if board == nil {
+		t.Fatalf("loading %v: no board", name)
+	}
+	return board
+}
+
+type buffer struct {
+	bytes.Buffer
+}
+
+func (b *buffer) Close() error {
+	return nil
+}
+
+func expect[T comparable](t *testing.T, what string, got, want T) {
+	t.Helper()
+	if got != want {
+		t.Errorf("%v: got %v, want %v", what, got, want)
+	}
+}
+
+//------------------------------------------------------------------------
+// Version 1
+//------------------------------------------------------------------------
+
+func TestLoadVersion1(t *testing.T) {
+	board := loadFixture(t, "v1.jpdy")
+	expect(t, "name", board.Name, "Science Night")
+	expect(t, "rounds", len(board.Rounds), 1)
+
+	round := board.Rounds[0]
+	expect(t, "round name", round.Name, "Jeopardy")
+	expect(t, "multiplier", round.GetMultiplier(), 1)
+	expect(t, "categories", len(round.Categories), 2)
+	expect(t, "first category", round.Categories[0].Name, "Chemistry")
+	expect(t, "questions", len(round.Categories[0].Questions), 2)
+	expect(t, "answered", round.Categories[1].Questions[0].Answered, true)
+
+	style := board.GetStyle()
+	expect(t, "category color",
+		logic.ColorToHex(style.CategoryStyle.Color), "#0000ffff")
+	expect(t, "category text color",
+		logic.ColorToHex(style.CategoryStyle.TextColor), "#ffffffff")
+	expect(t, "question color",
+		logic.ColorToHex(style.QuestionStyle.Color), "#00000000")
+	expect(t, "question text color",
+		logic.ColorToHex(style.QuestionStyle.TextColor), "#000000ff")
+}
+
+//------------------------------------------------------------------------
+// Version 2
+//------------------------------------------------------------------------
+
+func TestLoadVersion2(t *testing.T) {
+	board := loadFixture(t, "v2.jpdy")
+	expect(t, "name", board.Name, "Trivia Night")
+	expect(t, "rounds", len(board.Rounds), 1)
+	expect(t, "categories", len(board.Rounds[0].Categories), 1)
+
+	question := board.Rounds[0].Categories[0].Questions[0]
+	expect(t, "daily double", question.DailyDouble, true)
+
+	expect(t, "players", len(board.Players), 2)
+	expect(t, "player name", board.Players[1].GetName(), "Sam")
+	expect(t, "player score", board.Players[1].GetScore(), -400)
+	expect(t, "player key", board.Players[1].GetKey(), "L")
+
+	settings := board.GetSettings()
+	expect(t, "lockout", settings.LockoutMillis, 500)
+	expect(t, "final time", settings.FinalSeconds, 45)
+	expect(t, "question time", settings.QuestionSeconds,
+		logic.NewSettings().QuestionSeconds)
+
+	if board.Final == nil {
+		t.Fatal("final: missing")
+	}
+	expect(t, "final", board.Final.Name, "Geography")
+	expect(t, "category color",
+		logic.ColorToHex(board.GetStyle().CategoryStyle.Color), "#060ce9ff")
+}
+
+//------------------------------------------------------------------------
+// Version 3
+//------------------------------------------------------------------------
+
+func checkRounds(t *testing.T, board *logic.Board) {
+	t.Helper()
+	expect(t, "rounds", len(board.Rounds), 2)
+	expect(t, "second round", board.Rounds[1].Name, "Double Jeopardy")
+	expect(t, "multiplier", board.Rounds[1].GetMultiplier(), 2)
+
+	question := board.Rounds[1].Categories[0].Questions[0]
+	expect(t, "daily double", question.DailyDouble, true)
+	expect(t, "time limit", question.TimeLimit, 15)
+}
+
+func TestLoadVersion3Unversioned(t *testing.T) {
+	board := loadFixture(t, "v3-unversioned.jpdy")
+	expect(t, "name", board.Name, "Two Rounds")
+	checkRounds(t, board)
+}
+
+func TestLoadVersion3(t *testing.T) {
+	board := loadFixture(t, "v3.jpdy")
+	expect(t, "name", board.Name, "Versioned")
+	checkRounds(t, board)
+}
+
+func TestRejectNewerVersion(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "future.jpdy"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var board *logic.Board
+	err = file.Load(f, &board)
+	if err == nil || !strings.Contains(err.Error(), "newer version") {
+		t.Errorf("expected a newer version error, got %v", err)
+	}
+}
+
+//------------------------------------------------------------------------
+// Saving
+//------------------------------------------------------------------------
+
+func TestSaveWritesHeader(t *testing.T) {
+	var out buffer
+	if err := file.Save(&out, logic.MakeBoard("Header")); err != nil {
+		t.Fatal(err)
+	}
+	var saved struct {
+		Format  string
+		Version int
+		Content map[string]interface{}
+	}
+	if err := json.Unmarshal(out.Bytes(), &saved); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, "format", saved.Format, file.Format)
+	expect(t, "version", saved.Version, file.CurrentVersion)
+	expect(t, "content name", saved.Content["Name"], interface{}("Header"))
+}
+
+func TestRoundTrip(t *testing.T) {
+	board := loadFixture(t, "v2.jpdy")
+
+	var out buffer
+	if err := file.Save(&out, board); err != nil {
+		t.Fatal(err)
+	}
+	var loaded *logic.Board
+	if err := file.Load(io.NopCloser(&out), &loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	before, _ := json.Marshal(board)
+	after, _ := json.Marshal(loaded)
+	expect(t, "round trip", string(after), string(before))
+}
@@ -0,0 +1,194 @@
+//========================================================================
+// migrate.go
+//========================================================================
+// Upgrades files saved with older versions of the format
+//
+// Author: Aidan McNay
+// Date: June 20th, 2024
+
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//------------------------------------------------------------------------
+// Format Versions
+//------------------------------------------------------------------------
+//  1: The original format, with colors stored as Go's color structs
+//  2: Colors are stored as "#rrggbbaa" hex strings
+//  3: Categories are grouped into rounds, and files have a header
+//
+// Files from before the header was added (versions 1 and 2, as well as
+// early version 3 files) are recognized by their contents
+
+type document = map[string]interface{}
+
+func detectVersion(data []byte) int {
+	var doc document
+	if json.Unmarshal(data, &doc) != nil {
+		return CurrentVersion
+	}
+	if _, ok := doc["Rounds"]; ok {
+		return 3
+	}
+	for _, style := range styles(doc) {
+		for _, key := range []string{"Color", "TextColor"} {
+			if _, ok := style[key].(map[string]interface{}); ok {
+				return 1
+			}
+		}
+	}
+	return 2
+}
+
+//------------------------------------------------------------------------
+// Define the Migrations
+//------------------------------------------------------------------------
+// migrations[v] upgrades a document from version v to version v+1
+
+type migration func(doc document) error
+
+var migrations = map[int]migration{
+	1: hexColors,
+	2: addRounds,
+}
+
+//------------------------------------------------------------------------
+// Migrate
+//------------------------------------------------------------------------
+// Upgrades content from the given version to the current one, one
+// version at a time
+
+func Migrate(content json.RawMessage, version int) (json.RawMessage, error) {
+	if version == CurrentVersion {
+		return content, nil
+	}
+	if version < 1 {
+		return nil, fmt.Errorf("unknown format version %v", version)
+	}
+
+	var doc document
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	for ; version < CurrentVersion; version++ {
+		if err := migrations[version](doc); err != nil {
+			return nil, fmt.Errorf("couldn't upgrade from version %v: %w",
+				version, err)
+		}
+	}
+	return json.Marshal(doc)
+}
+
+//------------------------------------------------------------------------
+// styles
+//------------------------------------------------------------------------
+// Finds each style stored in a board
+
+func styles(doc document) []document {
+	gameStyle, ok := doc["Style"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var found []document = nil
+	for _, key := range []string{"CategoryStyle", "QuestionStyle"} {
+		if style, ok := gameStyle[key].(map[string]interface{}); ok {
+			found = append(found, style)
+		}
+	}
+	return found
+}
+
+//------------------------------------------------------------------------
+// Version 1 -> 2: hexColors
+//------------------------------------------------------------------------
+// Go's color types were stored as their fields, such as {"R": 255, "G":
+// 0, "B": 0, "A": 255}, {"Y": 0} for grayscale, or {"A": 0} for alpha
+
+func hexColors(doc document) error {
+	for _, style := range styles(doc) {
+		for _, key := range []string{"Color", "TextColor"} {
+			fields, ok := style[key].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			hex, err := colorFieldsToHex(fields)
+			if err != nil {
+				return err
+			}
+			style[key] = hex
+		}
+	}
+	return nil
+}
+
+func colorFieldsToHex(fields map[string]interface{}) (string, error) {
+	// 16-bit colors have channels above 255, and are scaled down
+	var channels = map[string]int{}
+	wide := false
+	for name, v := range fields {
+		value, ok := v.(float64)
+		if !ok {
+			return "", fmt.Errorf("color channel %v isn't a number", name)
+		}
+		channels[name] = int(value)
+		wide = wide || (value > 0xff)
+	}
+	channel := func(name string, fallback int) int {
+		value, ok := channels[name]
+		if !ok {
+			return fallback
+		}
+		if wide {
+			return value >> 8
+		}
+		return value
+	}
+
+	var r, g, b, a int
+	switch {
+	case len(channels) == 1 && hasKey(channels, "Y"):
+		y := channel("Y", 0)
+		r, g, b, a = y, y, y, 0xff
+	case len(channels) == 1 && hasKey(channels, "A"):
+		r, g, b, a = 0xff, 0xff, 0xff, channel("A", 0xff)
+		if a == 0 {
+			r, g, b = 0, 0, 0
+		}
+	default:
+		r, g, b, a = channel("R", 0), channel("G", 0), channel("B", 0),
+			channel("A", 0xff)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", r, g, b, a), nil
+}
+
+func hasKey(m map[string]int, key string) bool {
+	_, ok := m[key]
+	return ok
+}
+
+//------------------------------------------------------------------------
+// Version 2 -> 3: addRounds
+//------------------------------------------------------------------------
+// Boards had a single grid of categories, which becomes their only round
+
+func addRounds(doc document) error {
+	categories, ok := doc["Categories"]
+	if !ok {
+		return nil
+	}
+	delete(doc, "Categories")
+	if categories == nil {
+		categories = []interface{}{}
+	}
+	doc["Rounds"] = []interface{}{
+		document{
+			"Name":       "Jeopardy",
+			"Categories": categories,
+			"Multiplier": 1,
+		},
+	}
+	return nil
+}
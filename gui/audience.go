@@ -0,0 +1,66 @@
+//========================================================================
+// audience.go
+//========================================================================
+// The window shown to the audience while playing, which never reveals an
+// answer before the host does
+//
+// Author: Aidan McNay
+// Date: June 17th, 2024
+
+package gui
+
+import (
+	"jeopardy/logic"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+)
+
+//------------------------------------------------------------------------
+// buzzedIn
+//------------------------------------------------------------------------
+// Announces who has buzzed in, if anyone
+
+func buzzedIn(game *logic.Game) fyne.CanvasObject {
+	label := centeredLabel("")
+	label.TextStyle = fyne.TextStyle{Bold: true}
+	if winner := game.Buzzer().Winner(); winner != nil {
+		label.SetText(winner.GetName() + " buzzed in!")
+	}
+	return label
+}
+
+//------------------------------------------------------------------------
+// audienceContent
+//------------------------------------------------------------------------
+// Draws the audience's view of the game
+
+func audienceContent(game *logic.Game, screen *playScreen) fyne.CanvasObject {
+	board := game.Board()
+	round := game.Round()
+	category, question := game.Question()
+
+	var content fyne.CanvasObject
+	switch game.Phase() {
+	case logic.PhaseBoard:
+		content = container.NewBorder(roundHeading(round), nil, nil, nil,
+			playBoard(round, board.GetStyle(), nil))
+	case logic.PhaseRoundOver:
+		content = textScreen(roundName(round)+" is over!",
+			"Up next: "+roundName(game.NextRound()))
+	case logic.PhaseWager:
+		content = textScreen(category.Name, "Daily Double!")
+	case logic.PhasePrompt:
+		below := append([]fyne.CanvasObject{buzzedIn(game)}, screen.clock(game)...)
+		content = mediaScreen(questionHeading(game), question.Prompt,
+			question.PromptImage, below...)
+	case logic.PhaseAnswer:
+		content = mediaScreen(questionHeading(game), question.Answer,
+			question.AnswerImage, screen.clock(game)...)
+	default:
+		content = finalAudience(game, screen)
+	}
+
+	return container.NewBorder(nil, nil, nil,
+		container.NewPadded(audienceScores(game)), content)
+}
@@ -0,0 +1,119 @@
+//========================================================================
+// autosave.go
+//========================================================================
+// Periodically autosaving the board, and offering to recover autosaves
+// after a crash
+//
+// Author: Aidan McNay
+// Date: June 19th, 2024
+
+package gui
+
+import (
+	"fmt"
+	"jeopardy/logic"
+	"log"
+	"os"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+const autosaveInterval = 30 * time.Second
+
+//------------------------------------------------------------------------
+// StartAutosave
+//------------------------------------------------------------------------
+// Autosaves any unsaved changes to the app's storage in the background
+
+func StartAutosave(a fyne.App) {
+	logic.SetAutosaveRoot(a.Storage().RootURI())
+	go func() {
+		ticker := time.NewTicker(autosaveInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := logic.AutosaveBoard(); err != nil {
+				log.Println("Couldn't autosave the board:", err)
+			}
+		}
+	}()
+}
+
+//------------------------------------------------------------------------
+// savedSince
+//------------------------------------------------------------------------
+// Whether the file at uri has been saved since the given time. Only local
+// files can be checked
+
+func savedSince(uri fyne.URI, t time.Time) bool {
+	if uri == nil || uri.Scheme() != "file" {
+		return false
+	}
+	info, err := os.Stat(uri.Path())
+	if err != nil {
+		return false
+	}
+	return info.ModTime().After(t)
+}
+
+//------------------------------------------------------------------------
+// OfferRecovery
+//------------------------------------------------------------------------
+// Offers to restore any board or game that was autosaved before the app
+// last closed unexpectedly
+
+func OfferRecovery(win fyne.Window) {
+	board, uri, saved, ok := logic.LoadBoardAutosave()
+	if !ok || savedSince(uri, saved) {
+		// The user saved their work after it was autosaved
+		logic.ClearBoardAutosave()
+		offerGameRecovery(win)
+		return
+	}
+
+	onConfirm := func(b bool) {
+		if b {
+			haveABoard = true
+			logic.RestoreBoard(board, uri)
+		} else {
+			logic.ClearBoardAutosave()
+		}
+		offerGameRecovery(win)
+	}
+	dialog.ShowConfirm(
+		"Recover Unsaved Changes",
+		fmt.Sprintf("Unsaved changes to %v were autosaved at %v. Restore them?",
+			board.Name, saved.Format("Jan 2 15:04")),
+		onConfirm,
+		win,
+	)
+}
+
+func offerGameRecovery(win fyne.Window) {
+	saved, ok := logic.LoadGameAutosave()
+	if !ok {
+		return
+	}
+
+	// Resuming replaces the current board, which may have just been
+	// recovered itself
+	onConfirm := func(b bool) {
+		if !b {
+			logic.ClearGameAutosave()
+			return
+		}
+		confirmDiscard(win, func() {
+			haveABoard = true
+			runGame(saved.Resume())
+		})
+	}
+	dialog.ShowConfirm(
+		"Resume Game",
+		fmt.Sprintf("A game of %v was interrupted at %v. Resume it? Its board "+
+			"will replace the current one.",
+			saved.Board.Name, saved.Time.Format("Jan 2 15:04")),
+		onConfirm,
+		win,
+	)
+}
@@ -14,6 +14,9 @@ import (
 	"jeopardy/assets"
 	"jeopardy/logic"
 	"jeopardy/style"
+	"math/rand"
+	"strconv"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -29,12 +32,10 @@ import (
 //------------------------------------------------------------------------
 // Adds a Swapper, to swap the two adjacent categories
 
-func addSwapper(idx1, idx2 int) fyne.CanvasObject {
+func addSwapper(round *logic.Round, idx1, idx2 int) fyne.CanvasObject {
 	swapIcon := theme.NewThemedResource(assets.ResourceSwapPng)
 	swapButton := widget.NewButtonWithIcon("", swapIcon, func() {
-		curr_board := logic.GetCurrBoard()
-		curr_board.SwapCategories(idx1, idx2)
-		logic.BoardChange()
+		logic.Apply(logic.SwapCategoriesCommand(round, idx1, idx2))
 	})
 	return container.NewVBox(swapButton, layout.NewSpacer())
 }
@@ -42,20 +43,18 @@ func addSwapper(idx1, idx2 int) fyne.CanvasObject {
 //------------------------------------------------------------------------
 // categoryExists
 //------------------------------------------------------------------------
-// Checks whether a category name already exists
-
-func categoryExists(name string) error {
-	board := logic.GetCurrBoard()
-	if board == nil {
-		return nil
-	}
-	for _, v := range board.Categories {
-		if name == v.Name {
-			errorText := fmt.Sprintf("%v already exists", name)
-			return errors.New(errorText)
+// Checks whether a category name already exists in the round
+
+func categoryExists(round *logic.Round) func(name string) error {
+	return func(name string) error {
+		for _, v := range round.Categories {
+			if name == v.Name {
+				errorText := fmt.Sprintf("%v already exists", name)
+				return errors.New(errorText)
+			}
 		}
+		return nil
 	}
-	return nil
 }
 
 //------------------------------------------------------------------------
@@ -63,12 +62,12 @@ func categoryExists(name string) error {
 //------------------------------------------------------------------------
 // Creates a dialogue to add a new category
 
-func addCategory(win fyne.Window) {
+func addCategory(win fyne.Window, round *logic.Round) {
 	openPopup()
 	newName := widget.NewEntry()
 	newName.Validator = validation.NewAllStrings(
 		validation.NewRegexp(`^.+$`, "Category must have a non-empty name"),
-		categoryExists,
+		categoryExists(round),
 	)
 
 	items := []*widget.FormItem{
@@ -79,9 +78,8 @@ func addCategory(win fyne.Window) {
 		if !b {
 			return
 		}
-		board := logic.GetCurrBoard()
-		board.Categories = append(board.Categories, logic.MakeCategory(newName.Text))
-		logic.BoardChange()
+		newCategory := logic.MakeCategory(newName.Text)
+		logic.Apply(logic.AddCategoryCommand(round, newCategory))
 	}
 
 	prompt := dialog.NewForm("New Category", "Add Category", "Cancel", items,
@@ -98,15 +96,15 @@ func addCategory(win fyne.Window) {
 //------------------------------------------------------------------------
 // addCategoryButton
 //------------------------------------------------------------------------
-// A button that adds a new category
+// A button that adds a new category to the round
 
-func addCategoryButton(win fyne.Window) *fyne.Container {
+func addCategoryButton(win fyne.Window, round *logic.Round) *fyne.Container {
 	currTheme := fyne.CurrentApp().Settings().Theme()
 	variant := fyne.CurrentApp().Settings().ThemeVariant()
 	color := currTheme.Color("category", variant)
 
 	button := style.NewColorButton("Add Category", color, func() {
-		addCategory(win)
+		addCategory(win, round)
 	})
 	return container.NewVBox(
 		button,
@@ -122,7 +120,7 @@ func addCategoryButton(win fyne.Window) *fyne.Container {
 //------------------------------------------------------------------------
 // Changes the board's name
 
-func changeBoardName(win fyne.Window, refresh func()) {
+func changeBoardName(win fyne.Window) {
 	openPopup()
 
 	newName := widget.NewEntry()
@@ -140,8 +138,7 @@ func changeBoardName(win fyne.Window, refresh func()) {
 			return
 		}
 		board := logic.GetCurrBoard()
-		board.Name = newName.Text
-		refresh()
+		logic.Apply(logic.SetCommand("Rename Board", &board.Name, newName.Text))
 	}
 
 	prompt := dialog.NewForm("Edit Board Name", "Save", "Cancel", items,
@@ -172,31 +169,80 @@ func boardNameButton(win fyne.Window) fyne.CanvasObject {
 		func() {})
 	button.TextSize = 20
 	button.OnTapped(func() {
-		changeBoardName(win, func() {
-			button.SetText(logic.GetCurrBoard().Name)
-		})
+		changeBoardName(win)
 	})
 	return button
 }
 
 //------------------------------------------------------------------------
-// Make a new widget to represent a board
+// placeDailyDoubles
 //------------------------------------------------------------------------
+// Creates a dialogue to randomly choose which questions are Daily Doubles
+// in each round
 
-func boardWidget(win fyne.Window) fyne.Widget {
-	curr_board := logic.GetCurrBoard()
+func placeDailyDoubles(win fyne.Window) {
+	board := logic.GetCurrBoard()
+	if board == nil {
+		return
+	}
+	if !canOpenPopup() {
+		return
+	}
+	openPopup()
 
-	var columns []fyne.CanvasObject = nil
-	if curr_board != nil {
-		for idx, v := range curr_board.Categories {
-			if idx != 0 {
-				columns = append(columns, addSwapper(idx, idx-1))
-			}
-			columns = append(columns, categoryGUI(win, v))
+	var items []*widget.FormItem = nil
+	var counts []*widget.Entry = nil
+	for idx, round := range board.Rounds {
+		newCount := widget.NewEntry()
+		newCount.Validator = isNonNegativeInt
+		newCount.Text = fmt.Sprintf("%v", min(idx+1, 2))
+
+		counts = append(counts, newCount)
+		items = append(items, widget.NewFormItem(round.Name, newCount))
+	}
+
+	onConfirm := func(b bool) {
+		closePopup()
+		if !b {
+			return
+		}
+		var placed []int = nil
+		for _, v := range counts {
+			count, _ := strconv.Atoi(v.Text)
+			placed = append(placed, count)
+		}
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		command, err := logic.PlaceDailyDoublesCommand(board, placed, r)
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
 		}
+		logic.Apply(command)
 	}
-	columns = append(columns, addCategoryButton(win))
-	gridLayout := container.NewHBox(columns...)
+
+	prompt := dialog.NewForm("Daily Doubles per Round", "Place", "Cancel", items,
+		onConfirm, win)
+
+	var height float32 = prompt.MinSize().Height
+	var width float32 = 400
+	newSize := fyne.NewSize(width, height)
+	prompt.Resize(newSize)
+
+	prompt.Show()
+}
+
+//------------------------------------------------------------------------
+// Make a new widget to represent a board
+//------------------------------------------------------------------------
+
+// The tab to stay on when the board is re-drawn. The tabs after the rounds
+// are counted back from the end (as negative indices), so that they stay
+// selected when rounds are added or deleted
+
+var selectedTab int = 0
+
+func boardWidget(win fyne.Window) fyne.Widget {
+	curr_board := logic.GetCurrBoard()
 
 	var boardLayout fyne.CanvasObject
 	if curr_board == nil {
@@ -204,23 +250,58 @@ func boardWidget(win fyne.Window) fyne.Widget {
 		label.Alignment = fyne.TextAlignCenter
 		boardLayout = label
 	} else {
-		players := widget.NewLabel("Hello, players!")
+		players := playersGUI(win)
 
-		spacerBoard := container.NewPadded(
+		spacerPlayers := container.NewPadded(
 			widget.NewLabel(""),
 		)
-		spacerPlayers := container.NewPadded(
+		spacerFinal := container.NewPadded(
 			widget.NewLabel(""),
 		)
-
-		tabs := container.NewAppTabs(
-			container.NewTabItem("Board",
-				container.NewHBox(spacerBoard, gridLayout)),
+		spacerSettings := container.NewPadded(
+			widget.NewLabel(""),
+		)
+		spacerProblems := container.NewPadded(
+			widget.NewLabel(""),
+		)
+		problems := curr_board.Validate()
+
+		var tabItems []*container.TabItem = nil
+		for idx, round := range curr_board.Rounds {
+			spacerRound := container.NewPadded(
+				widget.NewLabel(""),
+			)
+			tabItems = append(tabItems, container.NewTabItem(round.Name,
+				container.NewHBox(spacerRound, roundGUI(win, idx, round))))
+		}
+		tabItems = append(tabItems,
 			container.NewTabItem("Players",
 				container.NewHBox(spacerPlayers, players)),
+			container.NewTabItem("Final Jeopardy",
+				container.NewHBox(spacerFinal, finalGUI(win))),
+			container.NewTabItem("Settings",
+				container.NewHBox(spacerSettings, settingsGUI(win))),
+			container.NewTabItem(problemsTitle(problems),
+				container.NewHBox(spacerProblems, problemsGUI(win, problems))),
 		)
+
+		tabs := container.NewAppTabs(tabItems...)
 		tabs.SetTabLocation(container.TabLocationLeading)
 
+		// Stay on the same tab when the board is re-drawn
+		numRounds := len(curr_board.Rounds)
+		if selectedTab < 0 {
+			tabs.SelectIndex(max(len(tabs.Items)+selectedTab, 0))
+		} else {
+			tabs.SelectIndex(min(selectedTab, max(numRounds-1, 0)))
+		}
+		tabs.OnSelected = func(_ *container.TabItem) {
+			selectedTab = tabs.SelectedIndex()
+			if selectedTab >= numRounds {
+				selectedTab -= len(tabs.Items)
+			}
+		}
+
 		name := boardNameButton(win)
 		boardLayout = container.NewVBox(name, tabs)
 	}
@@ -0,0 +1,133 @@
+//========================================================================
+// buzzer.go
+//========================================================================
+// An interface for players buzzing in while playing a board
+//
+// Author: Aidan McNay
+// Date: June 12th, 2024
+
+package gui
+
+import (
+	"fmt"
+	"jeopardy/logic"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// buzzerKeys
+//------------------------------------------------------------------------
+// Creates the key callbacks for each player to buzz in with
+
+func buzzerKeys(game *logic.Game) []keyCallback {
+	buzzer := game.Buzzer()
+	var callbacks []keyCallback = nil
+	for _, v := range game.Board().Players {
+		if v.GetKey() == "" {
+			continue
+		}
+		player := v
+		callbacks = append(callbacks, NewCallback(
+			fyne.KeyName(player.GetKey()),
+			0,
+			func() {
+				buzzer.Buzz(player, time.Now())
+			},
+		))
+	}
+	return callbacks
+}
+
+//------------------------------------------------------------------------
+// buzzerStatus
+//------------------------------------------------------------------------
+// Describes the state of the buzzers, including who's been locked out
+
+func buzzerStatus(board *logic.Board, buzzer *logic.Buzzer) string {
+	var status string
+	switch buzzer.State() {
+	case logic.BuzzerIdle:
+		return "Buzzers off"
+	case logic.BuzzerArmed:
+		status = "Buzzers closed"
+	case logic.BuzzerOpen:
+		status = "Buzzers open!"
+	case logic.BuzzerLocked:
+		return fmt.Sprintf("%v buzzed in!", buzzer.Winner().GetName())
+	}
+
+	now := time.Now()
+	var lockedOut []string = nil
+	var excluded []string = nil
+	for _, v := range board.Players {
+		if buzzer.IsExcluded(v) {
+			excluded = append(excluded, v.GetName())
+		} else if buzzer.IsLockedOut(v, now) {
+			lockedOut = append(lockedOut, v.GetName())
+		}
+	}
+	if len(lockedOut) > 0 {
+		status += fmt.Sprintf(" - locked out: %v", strings.Join(lockedOut, ", "))
+	}
+	if len(excluded) > 0 {
+		status += fmt.Sprintf(" - already answered: %v", strings.Join(excluded, ", "))
+	}
+	return status
+}
+
+//------------------------------------------------------------------------
+// buzzOrder
+//------------------------------------------------------------------------
+// Lists everyone who has buzzed in on the current question, in order
+
+func buzzOrder(buzzer *logic.Buzzer) string {
+	var names []string = nil
+	for _, v := range buzzer.Order() {
+		names = append(names, v.GetName())
+	}
+	if len(names) == 0 {
+		return "No one has buzzed in yet"
+	}
+	return "Buzz order: " + strings.Join(names, ", ")
+}
+
+//------------------------------------------------------------------------
+// buzzerControls
+//------------------------------------------------------------------------
+// Creates the host's controls for the buzzers, which show who buzzed in
+// and allow buzzing to be opened (or re-opened after a wrong answer)
+
+func buzzerControls(game *logic.Game) fyne.CanvasObject {
+	buzzer := game.Buzzer()
+
+	status := centeredLabel(buzzerStatus(game.Board(), buzzer))
+	status.TextStyle = fyne.TextStyle{Bold: true}
+
+	openButton := widget.NewButton("Open Buzzers", func() {
+		buzzer.Open()
+	})
+	reopenButton := widget.NewButton("Wrong Answer - Reopen", func() {
+		buzzer.Reopen()
+	})
+
+	switch buzzer.State() {
+	case logic.BuzzerArmed:
+		reopenButton.Disable()
+	case logic.BuzzerLocked:
+		openButton.Disable()
+	default:
+		openButton.Disable()
+		reopenButton.Disable()
+	}
+
+	return container.NewVBox(
+		status,
+		centeredLabel(buzzOrder(buzzer)),
+		container.NewCenter(container.NewHBox(openButton, reopenButton)),
+	)
+}
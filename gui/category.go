@@ -28,16 +28,14 @@ import (
 //------------------------------------------------------------------------
 // otherCategoryExists
 //------------------------------------------------------------------------
-// Checks whether a category name already exists, if it's not our original
-// name
+// Checks whether a category name already exists in the round, if it's not
+// our original name
 
-func otherCategoryExists(origName string) func(name string) error {
+func otherCategoryExists(round *logic.Round,
+	origName string,
+) func(name string) error {
 	return func(name string) error {
-		board := logic.GetCurrBoard()
-		if board == nil {
-			return nil
-		}
-		for _, v := range board.Categories {
+		for _, v := range round.Categories {
 			if (name == v.Name) && (name != origName) {
 				errorText := fmt.Sprintf("%v already exists", name)
 				return errors.New(errorText)
@@ -52,21 +50,20 @@ func otherCategoryExists(origName string) func(name string) error {
 //------------------------------------------------------------------------
 // Creates a dialogue to confirm deletion of a category
 
-func deleteCategory(category *logic.Category,
+func deleteCategory(round *logic.Round,
+	category *logic.Category,
 	form *dialog.FormDialog,
 	win fyne.Window,
 ) {
 	deleteCallback := func(b bool) {
 		if b {
-			curr_board := logic.GetCurrBoard()
-			curr_board.RemoveCategory(category)
 			form.Hide()
-			logic.BoardChange()
+			logic.Apply(logic.RemoveCategoryCommand(round, category))
 		}
 	}
 	dialog.ShowConfirm(
 		fmt.Sprintf("Delete %v", category.Name),
-		"Are you sure? This action can't be undone",
+		"Are you sure? You can undo this from the Board menu",
 		deleteCallback,
 		win,
 	)
@@ -77,13 +74,16 @@ func deleteCategory(category *logic.Category,
 //------------------------------------------------------------------------
 // Creates a dialogue to edit the category
 
-func editCategory(win fyne.Window, category *logic.Category) {
+func editCategory(win fyne.Window,
+	round *logic.Round,
+	category *logic.Category,
+) {
 	openPopup()
 	newName := widget.NewEntry()
 	newName.SetText(category.Name)
 	newName.Validator = validation.NewAllStrings(
 		validation.NewRegexp(`^.+$`, "Category must have a non-empty name"),
-		otherCategoryExists(category.Name),
+		otherCategoryExists(round, category.Name),
 	)
 
 	deleteButton := widget.NewButtonWithIcon("", theme.CancelIcon(),
@@ -99,14 +99,14 @@ func editCategory(win fyne.Window, category *logic.Category) {
 		if !b {
 			return
 		}
-		category.Name = newName.Text
-		logic.BoardChange()
+		logic.Apply(logic.SetCommand("Rename Category", &category.Name,
+			newName.Text))
 	}
 
 	prompt := dialog.NewForm("Edit Category", "Save", "Cancel", items,
 		onConfirm, win)
 	deleteButton.OnTapped = func() {
-		deleteCategory(category, prompt, win)
+		deleteCategory(round, category, prompt, win)
 	}
 
 	var height float32 = prompt.MinSize().Height
@@ -122,9 +122,12 @@ func editCategory(win fyne.Window, category *logic.Category) {
 //------------------------------------------------------------------------
 // Creates the button to edit a category
 
-func categoryButton(win fyne.Window, category *logic.Category) fyne.CanvasObject {
+func categoryButton(win fyne.Window,
+	round *logic.Round,
+	category *logic.Category,
+) fyne.CanvasObject {
 	name := widget.NewButton(category.Name, func() {
-		editCategory(win, category)
+		editCategory(win, round, category)
 	})
 	name.Importance = widget.LowImportance
 
@@ -165,11 +168,19 @@ func addQuestion(win fyne.Window, category *logic.Category) {
 	newPoints := widget.NewEntry()
 	newPoints.Validator = isInt
 
+	newDailyDouble := widget.NewCheck("", func(bool) {})
+
+	newTimeLimit := timeLimitEntry(0)
+
 	items := []*widget.FormItem{
 		widget.NewFormItem("Prompt", newPrompt),
 		widget.NewFormItem("Answer", newAnswer),
 		widget.NewFormItem("Points", newPoints),
+		widget.NewFormItem("Daily Double", newDailyDouble),
+		widget.NewFormItem("Time Limit (s)", newTimeLimit),
 	}
+	mediaItems, applyMedia := mediaFormItems(win, &logic.Question{})
+	items = append(items, mediaItems...)
 	onConfirm := func(b bool) {
 		closePopup()
 		if !b {
@@ -179,8 +190,10 @@ func addQuestion(win fyne.Window, category *logic.Category) {
 		answer := newAnswer.Text
 		points, _ := strconv.Atoi(newPoints.Text)
 		newQuestion := logic.MakeQuestion(prompt, answer, points)
-		category.AddQuestions(newQuestion)
-		logic.BoardChange()
+		newQuestion.DailyDouble = newDailyDouble.Checked
+		newQuestion.TimeLimit, _ = strconv.Atoi(newTimeLimit.Text)
+		applyMedia(newQuestion)
+		logic.Apply(logic.AddQuestionCommand(category, newQuestion))
 	}
 
 	formTitle := fmt.Sprintf("New Question for %v", category.Name)
@@ -215,10 +228,13 @@ func addQuestionButton(win fyne.Window, category *logic.Category) fyne.CanvasObj
 // Make a new Category element
 //------------------------------------------------------------------------
 
-func categoryGUI(win fyne.Window, category *logic.Category) fyne.CanvasObject {
+func categoryGUI(win fyne.Window,
+	round *logic.Round,
+	category *logic.Category,
+) fyne.CanvasObject {
 	var rows []fyne.CanvasObject = nil
 
-	rows = append(rows, categoryButton(win, category))
+	rows = append(rows, categoryButton(win, round, category))
 	for _, v := range category.Questions {
 		rows = append(rows, questionButton(win, category, v))
 	}
@@ -0,0 +1,79 @@
+//========================================================================
+// countdown.go
+//========================================================================
+// A shrinking bar showing how much time is left to respond
+//
+// Author: Aidan McNay
+// Date: June 15th, 2024
+
+package gui
+
+import (
+	"fmt"
+	"jeopardy/logic"
+	"time"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// Define a countdown Type
+//------------------------------------------------------------------------
+// Every window shows its own countdown, but they all follow the game's
+// clock
+
+type countdown struct {
+	bar      *widget.ProgressBar
+	game     *logic.Game
+	stopChan chan struct{}
+}
+
+const countdownTick = 100 * time.Millisecond
+
+func newCountdown(game *logic.Game) *countdown {
+	bar := widget.NewProgressBar()
+	bar.TextFormatter = func() string {
+		return fmt.Sprintf("%.0f s", bar.Value)
+	}
+	c := &countdown{
+		bar:      bar,
+		game:     game,
+		stopChan: make(chan struct{}),
+	}
+	c.update(time.Now())
+	go c.run()
+	return c
+}
+
+//------------------------------------------------------------------------
+// run
+//------------------------------------------------------------------------
+// Keeps the bar up to date until the countdown is stopped
+
+func (c *countdown) run() {
+	ticker := time.NewTicker(countdownTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stopChan:
+			return
+		case now := <-ticker.C:
+			c.update(now)
+		}
+	}
+}
+
+func (c *countdown) update(now time.Time) {
+	remaining, length := c.game.Clock(now)
+	c.bar.Max = length.Seconds()
+	c.bar.SetValue(remaining.Seconds())
+}
+
+//------------------------------------------------------------------------
+// stop
+//------------------------------------------------------------------------
+// Stops updating the bar, leaving it where it is
+
+func (c *countdown) stop() {
+	close(c.stopChan)
+}
@@ -0,0 +1,126 @@
+//========================================================================
+// export.go
+//========================================================================
+// Exporting the board to other formats
+//
+// Author: Aidan McNay
+// Date: June 25th, 2024
+
+package gui
+
+import (
+	"errors"
+	"fmt"
+	"jeopardy/logic"
+	"jeopardy/transfer"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// exportBoard
+//------------------------------------------------------------------------
+// Asks where to export the current board, then writes it there
+
+func exportBoard(win fyne.Window,
+	exporter *transfer.Exporter,
+	options transfer.Options,
+) {
+	board := logic.GetCurrBoard()
+	if !canOpenPopup() {
+		return
+	}
+	openPopup()
+
+	fd := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		closePopup()
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if writer == nil {
+			// Cancelled
+			return
+		}
+
+		err = errors.Join(exporter.Write(writer, board, options), writer.Close())
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("couldn't export %v: %w",
+				writer.URI().Name(), err), win)
+		}
+	}, win)
+	fd.SetFileName(board.Name + exporter.Extension)
+	fd.SetFilter(storage.NewExtensionFileFilter([]string{exporter.Extension}))
+	fd.Show()
+}
+
+//------------------------------------------------------------------------
+// chooseExportOptions
+//------------------------------------------------------------------------
+// Lets the user turn the format's options on or off before exporting, if
+// it has any
+
+func chooseExportOptions(win fyne.Window, exporter *transfer.Exporter) {
+	if logic.GetCurrBoard() == nil {
+		dialog.ShowInformation("No Board", "Create or open a board to export", win)
+		return
+	}
+	options := exporter.Defaults()
+	if len(exporter.Options) == 0 {
+		exportBoard(win, exporter, options)
+		return
+	}
+	if !canOpenPopup() {
+		return
+	}
+	openPopup()
+
+	var items []*widget.FormItem = nil
+	for _, v := range exporter.Options {
+		name := v.Name
+		check := widget.NewCheck("", func(checked bool) {
+			options[name] = checked
+		})
+		check.Checked = options[name]
+		items = append(items, widget.NewFormItem(v.Description, check))
+	}
+	onConfirm := func(b bool) {
+		closePopup()
+		if b {
+			exportBoard(win, exporter, options)
+		}
+	}
+
+	formTitle := fmt.Sprintf("Export %v", exporter.Description)
+	prompt := dialog.NewForm(formTitle, "Export", "Cancel", items,
+		onConfirm, win)
+
+	var height float32 = prompt.MinSize().Height
+	var width float32 = 400
+	newSize := fyne.NewSize(width, height)
+	prompt.Resize(newSize)
+
+	prompt.Show()
+}
+
+//------------------------------------------------------------------------
+// exportMenuItem
+//------------------------------------------------------------------------
+// A submenu with every format the board can be exported to
+
+func exportMenuItem(win fyne.Window) *fyne.MenuItem {
+	menuItem := fyne.NewMenuItem("Export", nil)
+	var formats []*fyne.MenuItem = nil
+	for _, v := range transfer.Exporters {
+		exporter := v
+		formats = append(formats, fyne.NewMenuItem(exporter.Description+"...",
+			func() {
+				chooseExportOptions(win, exporter)
+			}))
+	}
+	menuItem.ChildMenu = fyne.NewMenu("", formats...)
+	return menuItem
+}
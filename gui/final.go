@@ -0,0 +1,349 @@
+//========================================================================
+// final.go
+//========================================================================
+// Interfaces for editing and playing Final Jeopardy
+//
+// Author: Aidan McNay
+// Date: June 15th, 2024
+
+package gui
+
+import (
+	"fmt"
+	"jeopardy/logic"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/validation"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// editFinal
+//------------------------------------------------------------------------
+// Creates a dialogue to set the Final Jeopardy category and question
+
+func editFinal(win fyne.Window) {
+	openPopup()
+	board := logic.GetCurrBoard()
+
+	newCategory := widget.NewEntry()
+	newCategory.Validator = validation.NewRegexp(`^.+$`, "Category must have a non-empty name")
+
+	newPrompt := widget.NewMultiLineEntry()
+	newPrompt.Validator = validation.NewRegexp(`^.+$`, "Prompt must be non-empty")
+
+	newAnswer := widget.NewMultiLineEntry()
+	newAnswer.Validator = validation.NewRegexp(`^.+$`, "Answer must be non-empty")
+
+	if question := board.FinalQuestion(); question != nil {
+		newCategory.Text = board.Final.Name
+		newPrompt.Text = question.Prompt
+		newAnswer.Text = question.Answer
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Category", newCategory),
+		widget.NewFormItem("Prompt", newPrompt),
+		widget.NewFormItem("Answer", newAnswer),
+	}
+	onConfirm := func(b bool) {
+		closePopup()
+		if !b {
+			return
+		}
+		logic.Apply(logic.SetFinalCommand(board, newCategory.Text,
+			newPrompt.Text, newAnswer.Text))
+	}
+
+	prompt := dialog.NewForm("Final Jeopardy", "Save", "Cancel", items,
+		onConfirm, win)
+
+	var height float32 = prompt.MinSize().Height
+	var width float32 = 400
+	newSize := fyne.NewSize(width, height)
+	prompt.Resize(newSize)
+
+	prompt.Show()
+}
+
+//------------------------------------------------------------------------
+// removeFinal
+//------------------------------------------------------------------------
+// Creates a dialogue to confirm removing Final Jeopardy
+
+func removeFinal(win fyne.Window) {
+	removeCallback := func(b bool) {
+		if b {
+			logic.Apply(logic.RemoveFinalCommand(logic.GetCurrBoard()))
+		}
+	}
+	dialog.ShowConfirm(
+		"Remove Final Jeopardy",
+		"Are you sure? You can undo this from the Board menu",
+		removeCallback,
+		win,
+	)
+}
+
+//------------------------------------------------------------------------
+// Make a new Final Jeopardy element
+//------------------------------------------------------------------------
+
+func finalGUI(win fyne.Window) fyne.CanvasObject {
+	board := logic.GetCurrBoard()
+	question := board.FinalQuestion()
+	if question == nil {
+		addButton := widget.NewButtonWithIcon("Add Final Jeopardy",
+			theme.ContentAddIcon(), func() {
+				editFinal(win)
+			})
+		return container.NewVBox(
+			widget.NewLabel("This board has no Final Jeopardy"),
+			addButton,
+			layout.NewSpacer(),
+		)
+	}
+
+	prompt := widget.NewLabel(question.Prompt)
+	prompt.Wrapping = fyne.TextWrapWord
+	answer := widget.NewLabel(question.Answer)
+	answer.Wrapping = fyne.TextWrapWord
+
+	form := widget.NewForm(
+		widget.NewFormItem("Category", widget.NewLabel(board.Final.Name)),
+		widget.NewFormItem("Prompt", prompt),
+		widget.NewFormItem("Answer", answer),
+	)
+	editButton := widget.NewButton("Edit Final Jeopardy", func() {
+		editFinal(win)
+	})
+	removeButton := widget.NewButtonWithIcon("", theme.CancelIcon(), func() {
+		removeFinal(win)
+	})
+	removeButton.Importance = widget.DangerImportance
+
+	return container.NewVBox(
+		form,
+		container.NewHBox(editButton, removeButton),
+		layout.NewSpacer(),
+	)
+}
+
+//------------------------------------------------------------------------
+// finalPlaying
+//------------------------------------------------------------------------
+// Describes who is able to play Final Jeopardy
+
+func finalPlaying(game *logic.Game) *widget.Label {
+	players := game.FinalPlayers()
+	if len(players) == 0 {
+		return centeredLabel("No players have a positive score, so no one can wager")
+	}
+	var names []string = nil
+	for _, v := range players {
+		names = append(names, v.GetName())
+	}
+	return centeredLabel("Playing: " + strings.Join(names, ", "))
+}
+
+//------------------------------------------------------------------------
+// finalResponder
+//------------------------------------------------------------------------
+// Shows whose response is being judged, and their wager once revealed
+
+func finalResponder(game *logic.Game) (name, wager *widget.Label) {
+	player, shown := game.Responder()
+	name = centeredLabel(fmt.Sprintf("%v's response", player.GetName()))
+	name.TextStyle = fyne.TextStyle{Bold: true}
+
+	wager = centeredLabel("Wager: ???")
+	if shown {
+		amount, _ := game.FinalWager(player)
+		wager.SetText(fmt.Sprintf("Wager: %v", amount))
+	}
+	return name, wager
+}
+
+//------------------------------------------------------------------------
+// finalWinner
+//------------------------------------------------------------------------
+// Crowns the player(s) with the highest score
+
+func finalWinner(board *logic.Board) string {
+	leaders := board.Leaders()
+	var names []string = nil
+	for _, v := range leaders {
+		names = append(names, v.GetName())
+	}
+
+	switch len(leaders) {
+	case 0:
+		return "Thanks for playing!"
+	case 1:
+		return fmt.Sprintf("%v wins with %v!", names[0], leaders[0].GetScore())
+	default:
+		return fmt.Sprintf("%v tie with %v!", strings.Join(names, " & "),
+			leaders[0].GetScore())
+	}
+}
+
+//------------------------------------------------------------------------
+// finalAudience
+//------------------------------------------------------------------------
+// What the audience sees of Final Jeopardy
+
+func finalAudience(game *logic.Game, screen *playScreen) fyne.CanvasObject {
+	board := game.Board()
+	heading := fmt.Sprintf("Final Jeopardy - %v", board.Final.Name)
+	question := board.FinalQuestion()
+
+	switch game.Phase() {
+	case logic.PhaseFinalPrompt:
+		return textScreen(heading, question.Prompt, screen.clock(game)...)
+	case logic.PhaseFinalResponses:
+		name, wager := finalResponder(game)
+		return textScreen(heading, question.Answer, name, wager)
+	case logic.PhaseFinalWinner:
+		return textScreen("Final Jeopardy", finalWinner(board))
+	default:
+		return textScreen("Final Jeopardy", board.Final.Name, finalPlaying(game))
+	}
+}
+
+//------------------------------------------------------------------------
+// collectFinalWager
+//------------------------------------------------------------------------
+// Creates a dialogue for a player to secretly enter their wager
+
+func collectFinalWager(game *logic.Game, player *logic.Player, win fyne.Window) {
+	newWager := widget.NewPasswordEntry()
+	newWager.Validator = func(s string) error {
+		if err := isInt(s); err != nil {
+			return err
+		}
+		wager, _ := strconv.Atoi(s)
+		return logic.ValidateFinalWager(wager, player.GetScore())
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Wager", newWager),
+	}
+	onConfirm := func(b bool) {
+		if !b {
+			return
+		}
+		wager, _ := strconv.Atoi(newWager.Text)
+		game.SetFinalWager(player, wager)
+	}
+
+	formTitle := fmt.Sprintf("%v's Wager (0 to %v)", player.GetName(), player.GetScore())
+	prompt := dialog.NewForm(formTitle, "Submit", "Cancel", items,
+		onConfirm, win)
+
+	var height float32 = prompt.MinSize().Height
+	var width float32 = 400
+	newSize := fyne.NewSize(width, height)
+	prompt.Resize(newSize)
+
+	prompt.Show()
+}
+
+//------------------------------------------------------------------------
+// finalHost
+//------------------------------------------------------------------------
+// The host's controls for running Final Jeopardy
+
+func finalHost(game *logic.Game, screen *playScreen, win fyne.Window) fyne.CanvasObject {
+	board := game.Board()
+	heading := fmt.Sprintf("Final Jeopardy - %v", board.Final.Name)
+	question := board.FinalQuestion()
+	answer := centeredLabel("Answer: " + question.Answer)
+	answer.Wrapping = fyne.TextWrapWord
+
+	switch game.Phase() {
+	case logic.PhaseFinalCategory:
+		var button *widget.Button
+		if len(game.FinalPlayers()) == 0 {
+			button = widget.NewButton("Show Prompt", game.ShowFinalPrompt)
+		} else {
+			button = widget.NewButton("Collect Wagers", game.CollectFinalWagers)
+		}
+		button.Importance = widget.HighImportance
+		return textScreen("Final Jeopardy", board.Final.Name,
+			finalPlaying(game), button)
+
+	case logic.PhaseFinalWagers:
+		rows := []fyne.CanvasObject{}
+		allWagered := true
+		for _, v := range game.FinalPlayers() {
+			player := v
+			button := widget.NewButton("Enter Wager", func() {
+				collectFinalWager(game, player, win)
+			})
+			if _, ok := game.FinalWager(player); ok {
+				button.SetText("Change Wager")
+				button.SetIcon(theme.ConfirmIcon())
+			} else {
+				allWagered = false
+			}
+			rows = append(rows, container.NewBorder(nil, nil,
+				widget.NewLabel(player.GetName()), button))
+		}
+		showButton := widget.NewButton("Show Prompt", game.ShowFinalPrompt)
+		showButton.Importance = widget.HighImportance
+		if !allWagered {
+			showButton.Disable()
+		}
+		rows = append(rows, showButton)
+		return textScreen("Final Jeopardy", board.Final.Name, rows...)
+
+	case logic.PhaseFinalPrompt:
+		startButton := widget.NewButton("Start Clock", game.StartClock)
+		now := time.Now()
+		if game.ClockRunning(now) || game.ClockExpired(now) {
+			startButton.Disable()
+		}
+		revealButton := widget.NewButton("Reveal Responses", game.StartFinalResponses)
+		revealButton.Importance = widget.HighImportance
+		below := append([]fyne.CanvasObject{answer}, screen.clock(game)...)
+		below = append(below,
+			container.NewGridWithColumns(2, startButton, revealButton))
+		return textScreen(heading, question.Prompt, below...)
+
+	case logic.PhaseFinalResponses:
+		name, wager := finalResponder(game)
+		_, shown := game.Responder()
+
+		revealButton := widget.NewButton("Reveal Wager", game.RevealFinalWager)
+		correctButton := widget.NewButtonWithIcon("Correct", theme.ConfirmIcon(), func() {
+			game.ScoreFinal(true)
+		})
+		correctButton.Importance = widget.SuccessImportance
+		incorrectButton := widget.NewButtonWithIcon("Incorrect", theme.CancelIcon(), func() {
+			game.ScoreFinal(false)
+		})
+		incorrectButton.Importance = widget.DangerImportance
+		if shown {
+			revealButton.Disable()
+		} else {
+			correctButton.Disable()
+			incorrectButton.Disable()
+		}
+		return textScreen(heading, question.Answer,
+			name,
+			wager,
+			container.NewGridWithColumns(3, revealButton, correctButton, incorrectButton),
+		)
+
+	default:
+		doneButton := widget.NewButton("Back to Board", game.EndFinal)
+		return textScreen("Final Jeopardy", finalWinner(board), doneButton)
+	}
+}
@@ -0,0 +1,126 @@
+//========================================================================
+// host.go
+//========================================================================
+// The host's console while playing, which shows the answers along with
+// the controls for running the game
+//
+// Author: Aidan McNay
+// Date: June 17th, 2024
+
+package gui
+
+import (
+	"jeopardy/logic"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// hostBoard
+//------------------------------------------------------------------------
+// Lets the host choose the next question, or start Final Jeopardy
+
+func hostBoard(game *logic.Game) fyne.CanvasObject {
+	round := game.Round()
+	content := container.NewBorder(roundHeading(round), nil, nil, nil,
+		playBoard(round, game.Board().GetStyle(), game.SelectQuestion))
+	if !game.CanStartFinal() {
+		return content
+	}
+
+	finalButton := widget.NewButton("Final Jeopardy", game.StartFinal)
+	finalButton.Importance = widget.HighImportance
+	return container.NewBorder(nil, container.NewPadded(finalButton), nil, nil,
+		content)
+}
+
+//------------------------------------------------------------------------
+// hostWager
+//------------------------------------------------------------------------
+// Has the host enter the wager for a Daily Double
+
+func hostWager(game *logic.Game, win fyne.Window) fyne.CanvasObject {
+	category, _ := game.Question()
+
+	wagerButton := widget.NewButton("Enter Wager", func() {
+		promptWager(game.Board(), game.Round(), win, game.SetWager)
+	})
+	wagerButton.Importance = widget.HighImportance
+	cancelButton := widget.NewButton("Back to Board", game.CancelQuestion)
+
+	return textScreen(category.Name, "Daily Double!",
+		container.NewGridWithColumns(2, cancelButton, wagerButton))
+}
+
+//------------------------------------------------------------------------
+// hostPrompt
+//------------------------------------------------------------------------
+// Shows the host the prompt along with its answer, and the buzzers. The
+// host plays any audio, as it comes from their speakers
+
+func hostPrompt(game *logic.Game,
+	screen *playScreen,
+	win fyne.Window,
+) fyne.CanvasObject {
+	_, question := game.Question()
+
+	answer := centeredLabel("Answer: " + question.Answer)
+	answer.Wrapping = fyne.TextWrapWord
+
+	below := append([]fyne.CanvasObject{answer},
+		audioButton("Play Prompt Audio", question.PromptAudio, win)...)
+	below = append(below, screen.clock(game)...)
+	if game.Wagerer() == nil {
+		below = append(below, buzzerControls(game))
+	}
+	revealButton := widget.NewButton("Show Answer", game.RevealAnswer)
+	revealButton.Importance = widget.HighImportance
+	below = append(below, revealButton)
+
+	return mediaScreen(questionHeading(game), question.Prompt,
+		question.PromptImage, below...)
+}
+
+//------------------------------------------------------------------------
+// hostContent
+//------------------------------------------------------------------------
+// Draws the host's view of the game
+
+func hostContent(game *logic.Game,
+	screen *playScreen,
+	win fyne.Window,
+) fyne.CanvasObject {
+	round := game.Round()
+	_, question := game.Question()
+
+	var content fyne.CanvasObject
+	switch game.Phase() {
+	case logic.PhaseBoard:
+		content = hostBoard(game)
+	case logic.PhaseRoundOver:
+		next := roundName(game.NextRound())
+		startButton := widget.NewButton("Start "+next, game.StartNextRound)
+		startButton.Importance = widget.HighImportance
+		content = textScreen(roundName(round)+" is over!", "Up next: "+next,
+			startButton)
+	case logic.PhaseWager:
+		content = hostWager(game, win)
+	case logic.PhasePrompt:
+		content = hostPrompt(game, screen, win)
+	case logic.PhaseAnswer:
+		doneButton := widget.NewButton("Back to Board", game.ReturnToBoard)
+		doneButton.Importance = widget.HighImportance
+		below := audioButton("Play Answer Audio", question.AnswerAudio, win)
+		below = append(below, screen.clock(game)...)
+		below = append(below, doneButton)
+		content = mediaScreen(questionHeading(game), question.Answer,
+			question.AnswerImage, below...)
+	default:
+		content = finalHost(game, screen, win)
+	}
+
+	return container.NewBorder(nil, nil, nil,
+		container.NewPadded(hostScores(game, win)), content)
+}
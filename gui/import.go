@@ -0,0 +1,276 @@
+//========================================================================
+// import.go
+//========================================================================
+// Importing questions from a spreadsheet
+//
+// Author: Aidan McNay
+// Date: June 24th, 2024
+
+package gui
+
+import (
+	"fmt"
+	"jeopardy/logic"
+	"jeopardy/transfer"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+const previewRows = 5
+
+//------------------------------------------------------------------------
+// importFromCSV
+//------------------------------------------------------------------------
+// Asks for a CSV file, then how its columns should be read
+
+func importFromCSV(win fyne.Window) {
+	if !canOpenPopup() {
+		return
+	}
+	openPopup()
+
+	fd := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		closePopup()
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if reader == nil {
+			// Cancelled
+			return
+		}
+		defer reader.Close()
+
+		table, err := transfer.ReadCSV(reader)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("couldn't read %v: %w",
+				reader.URI().Name(), err), win)
+			return
+		}
+		name := strings.TrimSuffix(reader.URI().Name(), reader.URI().Extension())
+		mapColumns(win, table, name)
+	}, win)
+	fd.SetFilter(storage.NewExtensionFileFilter([]string{".csv"}))
+	fd.Show()
+}
+
+//------------------------------------------------------------------------
+// Column Selection
+//------------------------------------------------------------------------
+// Columns are offered by their header if there is one, or by their
+// letter as in a spreadsheet otherwise
+
+const notMappedOption = "(None)"
+
+// Letters go from A to Z, then AA, AB and so on
+
+func columnLetters(idx int) string {
+	letters := ""
+	for idx++; idx > 0; idx = (idx - 1) / 26 {
+		letters = string(rune('A'+(idx-1)%26)) + letters
+	}
+	return letters
+}
+
+func columnOptions(table *transfer.Table, hasHeader bool) []string {
+	options := []string{notMappedOption}
+	for idx := 0; idx < table.Width(); idx++ {
+		option := "Column " + columnLetters(idx)
+		if hasHeader && idx < len(table.Rows[0]) && table.Rows[0][idx] != "" {
+			option += fmt.Sprintf(" (%v)", table.Rows[0][idx])
+		}
+		options = append(options, option)
+	}
+	return options
+}
+
+func columnSelect(options []string,
+	selected int,
+	onChanged func(idx int),
+) *widget.Select {
+	columnSelect := widget.NewSelect(options, nil)
+	columnSelect.SetSelectedIndex(selected + 1)
+	columnSelect.OnChanged = func(_ string) {
+		onChanged(columnSelect.SelectedIndex() - 1)
+	}
+	return columnSelect
+}
+
+//------------------------------------------------------------------------
+// importPreview
+//------------------------------------------------------------------------
+// Shows the first few rows as they'll be read with the current mapping
+
+func importPreview(table *transfer.Table,
+	mapping transfer.Mapping,
+	hasHeader bool,
+) fyne.CanvasObject {
+	var cells []fyne.CanvasObject = nil
+	for _, name := range transfer.ColumnNames {
+		label := widget.NewLabel(name)
+		label.TextStyle = fyne.TextStyle{Bold: true}
+		cells = append(cells, label)
+	}
+
+	rows := table.Rows
+	if hasHeader {
+		rows = rows[1:]
+	}
+	for _, row := range rows[:min(len(rows), previewRows)] {
+		for _, idx := range mapping {
+			text := ""
+			if idx != transfer.NotMapped && idx < len(row) {
+				text = row[idx]
+			}
+			label := widget.NewLabel(text)
+			label.Truncation = fyne.TextTruncateEllipsis
+			cells = append(cells, label)
+		}
+	}
+	return container.NewGridWithColumns(int(transfer.NumColumns), cells...)
+}
+
+//------------------------------------------------------------------------
+// mapColumns
+//------------------------------------------------------------------------
+// Lets the user choose which column holds what (starting from our best
+// guess), and whether to import into the current board or a new one
+
+const (
+	importIntoCurrent = "Current Board"
+	importIntoNew     = "New Board"
+)
+
+func mapColumns(win fyne.Window, table *transfer.Table, name string) {
+	if !canOpenPopup() {
+		return
+	}
+	openPopup()
+
+	mapping, hasHeader := table.GuessMapping()
+
+	preview := container.NewStack()
+	selects := container.NewVBox()
+	var refresh func()
+
+	header := widget.NewCheck("First row is a header", nil)
+	header.Checked = hasHeader
+	header.OnChanged = func(checked bool) {
+		hasHeader = checked
+		refresh()
+	}
+
+	refresh = func() {
+		options := columnOptions(table, hasHeader)
+		var items []*widget.FormItem = nil
+		for column, columnName := range transfer.ColumnNames {
+			column := column
+			items = append(items, widget.NewFormItem(columnName,
+				columnSelect(options, mapping[column], func(idx int) {
+					mapping[column] = idx
+					preview.Objects = []fyne.CanvasObject{
+						importPreview(table, mapping, hasHeader),
+					}
+					preview.Refresh()
+				})))
+		}
+		selects.Objects = []fyne.CanvasObject{widget.NewForm(items...)}
+		selects.Refresh()
+		preview.Objects = []fyne.CanvasObject{
+			importPreview(table, mapping, hasHeader),
+		}
+		preview.Refresh()
+	}
+	refresh()
+
+	into := widget.NewRadioGroup([]string{importIntoCurrent, importIntoNew}, nil)
+	into.Horizontal = true
+	if logic.GetCurrBoard() != nil {
+		into.SetSelected(importIntoCurrent)
+	} else {
+		into.SetSelected(importIntoNew)
+		into.Disable()
+	}
+	into.Required = true
+
+	content := container.NewVBox(
+		header,
+		selects,
+		widget.NewForm(widget.NewFormItem("Import Into", into)),
+		widget.NewSeparator(),
+		widget.NewLabel("Preview"),
+		preview,
+	)
+
+	onConfirm := func(b bool) {
+		closePopup()
+		if !b {
+			return
+		}
+		if into.Selected == importIntoNew {
+			confirmDiscard(win, func() {
+				board, imported, rowErrors := transfer.ImportBoard(name, table,
+					mapping, hasHeader)
+				logic.SetCurrBoard(board)
+				haveABoard = true
+				// The board hasn't been saved yet
+				logic.BoardChange()
+				showImportReport(win, imported, rowErrors)
+			})
+			return
+		}
+		cmd, imported, rowErrors := transfer.ImportCommand(
+			logic.GetCurrBoard(), table, mapping, hasHeader)
+		if imported > 0 {
+			logic.Apply(cmd)
+		}
+		showImportReport(win, imported, rowErrors)
+	}
+
+	prompt := dialog.NewCustomConfirm("Import Questions", "Import", "Cancel",
+		container.NewVScroll(content), onConfirm, win)
+
+	var height float32 = 600
+	var width float32 = 700
+	newSize := fyne.NewSize(width, height)
+	prompt.Resize(newSize)
+
+	prompt.Show()
+}
+
+//------------------------------------------------------------------------
+// showImportReport
+//------------------------------------------------------------------------
+// Tells the user how many questions were imported, and which rows
+// couldn't be
+
+func showImportReport(win fyne.Window, imported int, rowErrors []transfer.RowError) {
+	summary := fmt.Sprintf("Imported %v question(s)", imported)
+	if len(rowErrors) == 0 {
+		dialog.ShowInformation("Import Complete", summary, win)
+		return
+	}
+
+	summary += fmt.Sprintf(", but %v row(s) couldn't be read:", len(rowErrors))
+	var rows []fyne.CanvasObject = nil
+	for _, v := range rowErrors {
+		rows = append(rows, widget.NewLabel(v.Error()))
+	}
+	list := container.NewVScroll(container.NewVBox(rows...))
+	list.SetMinSize(fyne.NewSize(0, 200))
+
+	content := container.NewBorder(widget.NewLabel(summary), nil, nil, nil, list)
+	report := dialog.NewCustom("Import Complete", "OK", content, win)
+
+	var height float32 = report.MinSize().Height
+	var width float32 = 500
+	newSize := fyne.NewSize(width, height)
+	report.Resize(newSize)
+
+	report.Show()
+}
@@ -9,6 +9,8 @@
 package gui
 
 import (
+	"jeopardy/logic"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/driver/desktop"
 )
@@ -54,6 +56,31 @@ func (c keyCallback) addToWindow(win fyne.Window) {
 	win.Canvas().AddShortcut(c.shortcut(), c.trigger)
 }
 
+//------------------------------------------------------------------------
+// addKeysToWindow
+//------------------------------------------------------------------------
+// Fyne only delivers shortcuts that use a modifier, so callbacks for
+// plain keys are instead dispatched from the canvas' key handler. This
+// replaces any key handler previously set on the window
+
+func addKeysToWindow(win fyne.Window, callbacks ...keyCallback) {
+	onKey := func(event *fyne.KeyEvent) {
+		for _, c := range callbacks {
+			if c.shortcut().KeyName == event.Name {
+				c.Callback()
+			}
+		}
+	}
+
+	// Prefer key-down events where possible, so that holding a key
+	// doesn't repeatedly trigger it
+	if deskCanvas, ok := win.Canvas().(desktop.Canvas); ok {
+		deskCanvas.SetOnKeyDown(onKey)
+	} else {
+		win.Canvas().SetOnTypedKey(onKey)
+	}
+}
+
 //------------------------------------------------------------------------
 // Define shortcuts we'd like to have for the top-level canvas
 //------------------------------------------------------------------------
@@ -108,6 +135,43 @@ func styleShortcut(win fyne.Window) keyCallback {
 	)
 }
 
+func runShortcut(win fyne.Window) keyCallback {
+	return NewCallback(
+		fyne.KeyR,
+		fyne.KeyModifierShortcutDefault,
+		func() {
+			runBoard(win)
+		},
+	)
+}
+
+// Undoing and redoing are skipped while a popup is open, so that the
+// board doesn't change underneath it
+
+func undoShortcut(win fyne.Window) keyCallback {
+	return NewCallback(
+		fyne.KeyZ,
+		fyne.KeyModifierShortcutDefault,
+		func() {
+			if canOpenPopup() {
+				logic.Undo()
+			}
+		},
+	)
+}
+
+func redoShortcut(win fyne.Window) keyCallback {
+	return NewCallback(
+		fyne.KeyZ,
+		fyne.KeyModifierShortcutDefault|fyne.KeyModifierShift,
+		func() {
+			if canOpenPopup() {
+				logic.Redo()
+			}
+		},
+	)
+}
+
 //------------------------------------------------------------------------
 // Add the shortcuts to the top-level canvas
 //------------------------------------------------------------------------
@@ -118,4 +182,7 @@ func AddTopLevelShortcuts(win fyne.Window) {
 	saveBoardShortcut(win).addToWindow(win)
 	saveAsBoardShortcut(win).addToWindow(win)
 	styleShortcut(win).addToWindow(win)
+	runShortcut(win).addToWindow(win)
+	undoShortcut(win).addToWindow(win)
+	redoShortcut(win).addToWindow(win)
 }
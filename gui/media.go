@@ -0,0 +1,206 @@
+//========================================================================
+// media.go
+//========================================================================
+// Attaching images and audio to questions, and showing or playing them
+//
+// Author: Aidan McNay
+// Date: June 28th, 2024
+
+package gui
+
+import (
+	"errors"
+	"jeopardy/logic"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// Define the Kinds of Media
+//------------------------------------------------------------------------
+
+// Each kind of media is identified by the file extensions it can use
+
+type mediaKind []string
+
+var imageMedia = mediaKind{".png", ".jpg", ".jpeg", ".svg"}
+var audioMedia = mediaKind{".mp3", ".wav", ".ogg", ".m4a", ".flac"}
+
+//------------------------------------------------------------------------
+// mediaPicker
+//------------------------------------------------------------------------
+// Controls for choosing (or removing) an attachment, which start with the
+// current one. The returned function gives whatever is chosen
+
+func mediaPicker(win fyne.Window,
+	kind mediaKind,
+	current *fyne.StaticResource,
+) (fyne.CanvasObject, func() *fyne.StaticResource) {
+	chosen := current
+	name := widget.NewLabel("")
+	name.Truncation = fyne.TextTruncateEllipsis
+
+	var removeButton *widget.Button
+	update := func() {
+		if chosen == nil {
+			name.SetText("None")
+			removeButton.Disable()
+		} else {
+			name.SetText(chosen.Name())
+			removeButton.Enable()
+		}
+	}
+
+	chooseButton := widget.NewButtonWithIcon("", theme.FolderOpenIcon(), func() {
+		fd := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			if reader == nil {
+				// Cancelled
+				return
+			}
+			reader.Close()
+
+			resource, err := logic.ResourceFromURI(reader.URI())
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			chosen = resource
+			update()
+		}, win)
+		fd.SetFilter(storage.NewExtensionFileFilter(kind))
+		fd.Show()
+	})
+	removeButton = widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+		chosen = nil
+		update()
+	})
+	update()
+
+	buttons := container.NewHBox(chooseButton, removeButton)
+	picker := container.NewBorder(nil, nil, nil, buttons, name)
+	return picker, func() *fyne.StaticResource { return chosen }
+}
+
+//------------------------------------------------------------------------
+// mediaFormItems
+//------------------------------------------------------------------------
+// Pickers for each of a question's attachments, along with a function
+// that copies whatever is chosen onto a question
+
+func mediaFormItems(win fyne.Window,
+	question *logic.Question,
+) ([]*widget.FormItem, func(*logic.Question)) {
+	promptImage, getPromptImage := mediaPicker(win, imageMedia,
+		question.PromptImage)
+	promptAudio, getPromptAudio := mediaPicker(win, audioMedia,
+		question.PromptAudio)
+	answerImage, getAnswerImage := mediaPicker(win, imageMedia,
+		question.AnswerImage)
+	answerAudio, getAnswerAudio := mediaPicker(win, audioMedia,
+		question.AnswerAudio)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Prompt Image", promptImage),
+		widget.NewFormItem("Prompt Audio", promptAudio),
+		widget.NewFormItem("Answer Image", answerImage),
+		widget.NewFormItem("Answer Audio", answerAudio),
+	}
+	apply := func(q *logic.Question) {
+		q.PromptImage = getPromptImage()
+		q.PromptAudio = getPromptAudio()
+		q.AnswerImage = getAnswerImage()
+		q.AnswerAudio = getAnswerAudio()
+	}
+	return items, apply
+}
+
+//------------------------------------------------------------------------
+// mediaImage
+//------------------------------------------------------------------------
+// Shows an attached image, scaled to fit between the text and controls
+
+func mediaImage(image *fyne.StaticResource) fyne.CanvasObject {
+	picture := canvas.NewImageFromResource(image)
+	picture.FillMode = canvas.ImageFillContain
+	picture.SetMinSize(fyne.NewSize(320, 240))
+	return picture
+}
+
+//------------------------------------------------------------------------
+// playAudio
+//------------------------------------------------------------------------
+// Fyne can't play audio itself, so attachments are written to a temporary
+// file and opened with the system's default player. Each gets its own file
+// (attachments may share a name), all removed when the game ends
+
+var audioDir = ""
+
+func removeAudio() {
+	if audioDir == "" {
+		return
+	}
+	if err := os.RemoveAll(audioDir); err != nil {
+		log.Println("Couldn't remove played audio:", err)
+	}
+	audioDir = ""
+}
+
+func playAudio(audio *fyne.StaticResource, win fyne.Window) {
+	if audioDir == "" {
+		dir, err := os.MkdirTemp("", "jeopardy-audio")
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		audioDir = dir
+	}
+
+	f, err := os.CreateTemp(audioDir, "*"+filepath.Ext(audio.Name()))
+	if err != nil {
+		dialog.ShowError(err, win)
+		return
+	}
+	path := f.Name()
+	_, err = f.Write(audio.Content())
+	if err := errors.Join(err, f.Close()); err != nil {
+		dialog.ShowError(err, win)
+		return
+	}
+	fileURL, err := url.Parse(storage.NewFileURI(path).String())
+	if err != nil {
+		dialog.ShowError(err, win)
+		return
+	}
+	if err := fyne.CurrentApp().OpenURL(fileURL); err != nil {
+		dialog.ShowError(err, win)
+	}
+}
+
+// A button that plays the audio, or nothing if there isn't any
+
+func audioButton(label string,
+	audio *fyne.StaticResource,
+	win fyne.Window,
+) []fyne.CanvasObject {
+	if audio == nil {
+		return nil
+	}
+	button := widget.NewButtonWithIcon(label, theme.MediaPlayIcon(), func() {
+		playAudio(audio, win)
+	})
+	return []fyne.CanvasObject{button}
+}
@@ -9,6 +9,8 @@
 package gui
 
 import (
+	"jeopardy/logic"
+
 	"fyne.io/fyne/v2"
 )
 
@@ -60,23 +62,121 @@ func styleMenuItem(win fyne.Window) *fyne.MenuItem {
 	return menuItem
 }
 
+func runMenuItem(win fyne.Window) *fyne.MenuItem {
+	callback := runShortcut(win)
+	menuItem := menuItemFromCallback("Run Game", callback)
+	return menuItem
+}
+
+func dailyDoubleMenuItem(win fyne.Window) *fyne.MenuItem {
+	return fyne.NewMenuItem("Place Daily Doubles...", func() {
+		placeDailyDoubles(win)
+	})
+}
+
+func importMenuItem(win fyne.Window) *fyne.MenuItem {
+	return fyne.NewMenuItem("Import Questions from CSV...", func() {
+		importFromCSV(win)
+	})
+}
+
+func undoMenuItem(win fyne.Window) *fyne.MenuItem {
+	callback := undoShortcut(win)
+	menuItem := menuItemFromCallback("Undo", callback)
+	return menuItem
+}
+
+func redoMenuItem(win fyne.Window) *fyne.MenuItem {
+	callback := redoShortcut(win)
+	menuItem := menuItemFromCallback("Redo", callback)
+	return menuItem
+}
+
+// Whether to keep a backup is remembered between sessions
+
+const keepBackupsPreference = "keepBackups"
+
+func InitBackups(a fyne.App) {
+	keep := a.Preferences().BoolWithFallback(keepBackupsPreference, false)
+	logic.SetKeepBackups(keep)
+}
+
+func backupMenuItem(refresh func()) *fyne.MenuItem {
+	var menuItem *fyne.MenuItem
+	menuItem = fyne.NewMenuItem("Keep Backup When Saving", func() {
+		keep := !logic.KeepBackups()
+		logic.SetKeepBackups(keep)
+		fyne.CurrentApp().Preferences().SetBool(keepBackupsPreference, keep)
+		menuItem.Checked = keep
+		refresh()
+	})
+	menuItem.Checked = logic.KeepBackups()
+	return menuItem
+}
+
+//------------------------------------------------------------------------
+// updateHistoryItems
+//------------------------------------------------------------------------
+// Names the edits that undo and redo would affect, disabling them if
+// there aren't any
+
+func updateHistoryItems(undo, redo *fyne.MenuItem) {
+	history := logic.GetHistory()
+
+	undo.Label = "Undo"
+	if name := history.NextUndo(); name != "" {
+		undo.Label += " " + name
+	}
+	undo.Disabled = history.NextUndo() == ""
+
+	redo.Label = "Redo"
+	if name := history.NextRedo(); name != "" {
+		redo.Label += " " + name
+	}
+	redo.Disabled = history.NextRedo() == ""
+}
+
 //------------------------------------------------------------------------
 // Define our "Board" menu based on our menu items
 //------------------------------------------------------------------------
 
 func boardMenu(win fyne.Window) *fyne.Menu {
+	undo := undoMenuItem(win)
+	redo := redoMenuItem(win)
+	updateHistoryItems(undo, redo)
+
+	var menu *fyne.Menu
+	refresh := func() {
+		menu.Refresh()
+	}
+
 	items := [](*fyne.MenuItem){
 		newBoardMenuItem(win),
 		loadBoardMenuItem(win),
 		saveBoardMenuItem(win),
 		saveAsBoardMenuItem(win),
+		backupMenuItem(refresh),
+		fyne.NewMenuItemSeparator(),
+		importMenuItem(win),
+		exportMenuItem(win),
+		fyne.NewMenuItemSeparator(),
+		undo,
+		redo,
 		fyne.NewMenuItemSeparator(),
 		styleMenuItem(win),
+		dailyDoubleMenuItem(win),
+		runMenuItem(win),
 	}
-	return fyne.NewMenu(
+	menu = fyne.NewMenu(
 		"Board",
 		items...,
 	)
+
+	logic.OnBoardChange(func(_ *logic.Board) {
+		updateHistoryItems(undo, redo)
+		menu.Refresh()
+	})
+	return menu
 }
 
 //------------------------------------------------------------------------
@@ -0,0 +1,349 @@
+//========================================================================
+// play.go
+//========================================================================
+// An interface for running a board as an interactive game, with one
+// window for the audience and another for the host
+//
+// Author: Aidan McNay
+// Date: June 10th, 2024
+
+package gui
+
+import (
+	"fmt"
+	"image/color"
+	"jeopardy/logic"
+	"jeopardy/style"
+	"log"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// Colors used when playing
+//------------------------------------------------------------------------
+
+var answeredColor color.Color = color.NRGBA{0x80, 0x80, 0x80, 0xff}
+
+func themeColor(name fyne.ThemeColorName) color.Color {
+	currTheme := fyne.CurrentApp().Settings().Theme()
+	variant := fyne.CurrentApp().Settings().ThemeVariant()
+	return currTheme.Color(name, variant)
+}
+
+//------------------------------------------------------------------------
+// bigText
+//------------------------------------------------------------------------
+// Creates a large, centered, wrapping block of text, for displaying
+// prompts and answers
+
+func bigText(text string) *widget.RichText {
+	segment := &widget.TextSegment{
+		Text: text,
+		Style: widget.RichTextStyle{
+			Alignment: fyne.TextAlignCenter,
+			ColorName: theme.ColorNameForeground,
+			SizeName:  theme.SizeNameHeadingText,
+			TextStyle: fyne.TextStyle{Bold: true},
+		},
+	}
+	richText := widget.NewRichText(segment)
+	richText.Wrapping = fyne.TextWrapWord
+	return richText
+}
+
+//------------------------------------------------------------------------
+// playTile
+//------------------------------------------------------------------------
+// Creates the tile for a single question on the game board
+
+func playTile(s *logic.Style,
+	question *logic.Question,
+	value int,
+	onTap func(),
+) fyne.CanvasObject {
+	if question == nil {
+		return layout.NewSpacer()
+	}
+	if question.Answered {
+		tile := style.NewColorButton("", answeredColor, func() {})
+		return tile
+	}
+	return styledTile(
+		s,
+		style.ColorNameQuestion,
+		fmt.Sprintf("%v", value),
+		30,
+		onTap,
+	)
+}
+
+//------------------------------------------------------------------------
+// playBoard
+//------------------------------------------------------------------------
+// Creates the grid of a round's categories and point tiles, calling
+// onSelect (if not nil) when an unanswered question is chosen
+
+func playBoard(round *logic.Round,
+	gameStyle *logic.GameStyle,
+	onSelect func(*logic.Category, *logic.Question),
+) fyne.CanvasObject {
+	width := round.Width()
+	height := round.Height()
+	if round == nil {
+		label := widget.NewLabel("The board has no rounds")
+		label.Alignment = fyne.TextAlignCenter
+		return container.NewCenter(label)
+	}
+	if width == 0 {
+		label := widget.NewLabel(round.Name + " has no categories")
+		label.Alignment = fyne.TextAlignCenter
+		return container.NewCenter(label)
+	}
+
+	var tiles []fyne.CanvasObject = nil
+	for _, category := range round.Categories {
+		header := styledTile(
+			gameStyle.CategoryStyle,
+			style.ColorNameCategory,
+			category.Name,
+			20,
+			func() {},
+		)
+		tiles = append(tiles, header)
+	}
+	for row := 1; row < height; row++ {
+		for _, category := range round.Categories {
+			var question *logic.Question = nil
+			if row < category.Height() {
+				question = category.Questions[row-1]
+			}
+			tile := playTile(gameStyle.QuestionStyle, question,
+				round.Value(question), func() {
+					if onSelect != nil {
+						onSelect(category, question)
+					}
+				})
+			tiles = append(tiles, tile)
+		}
+	}
+	return container.NewPadded(container.NewGridWithColumns(width, tiles...))
+}
+
+//------------------------------------------------------------------------
+// textScreen
+//------------------------------------------------------------------------
+// Lays out a heading above a large block of text, with any other objects
+// below it
+
+func textScreen(heading string,
+	text string,
+	below ...fyne.CanvasObject,
+) fyne.CanvasObject {
+	return mediaScreen(heading, text, nil, below...)
+}
+
+// Shows an image (if not nil) beneath the text, such as one attached to a
+// prompt or answer
+
+func mediaScreen(heading string,
+	text string,
+	image *fyne.StaticResource,
+	below ...fyne.CanvasObject,
+) fyne.CanvasObject {
+	title := widget.NewLabel(heading)
+	title.Alignment = fyne.TextAlignCenter
+	title.TextStyle = fyne.TextStyle{Bold: true}
+
+	center := []fyne.CanvasObject{layout.NewSpacer(), bigText(text)}
+	if image != nil {
+		center = append(center, mediaImage(image))
+	}
+	center = append(center, layout.NewSpacer())
+
+	return container.NewBorder(
+		title,
+		container.NewPadded(container.NewVBox(below...)),
+		nil,
+		nil,
+		container.NewVBox(center...),
+	)
+}
+
+//------------------------------------------------------------------------
+// centeredLabel
+//------------------------------------------------------------------------
+
+func centeredLabel(text string) *widget.Label {
+	label := widget.NewLabel(text)
+	label.Alignment = fyne.TextAlignCenter
+	return label
+}
+
+//------------------------------------------------------------------------
+// roundHeading
+//------------------------------------------------------------------------
+// Shows the name of the round being played. Rounds can be deleted in the
+// editor during a game, so there may not be one
+
+func roundName(round *logic.Round) string {
+	if round == nil {
+		return "No Rounds"
+	}
+	return round.Name
+}
+
+func roundHeading(round *logic.Round) fyne.CanvasObject {
+	heading := centeredLabel(roundName(round))
+	heading.TextStyle = fyne.TextStyle{Bold: true}
+	return heading
+}
+
+//------------------------------------------------------------------------
+// questionHeading
+//------------------------------------------------------------------------
+// Describes the question being played
+
+func questionHeading(game *logic.Game) string {
+	category, _ := game.Question()
+	if wagerer := game.Wagerer(); wagerer != nil {
+		return fmt.Sprintf("%v - Daily Double! %v wagers %v",
+			category.Name, wagerer.GetName(), game.Value())
+	}
+	return fmt.Sprintf("%v - %v", category.Name, game.Value())
+}
+
+//------------------------------------------------------------------------
+// Define a playScreen Type
+//------------------------------------------------------------------------
+// The contents of a play window, which are re-drawn whenever the game
+// changes
+
+type playScreen struct {
+	view      *fyne.Container
+	countdown *countdown
+}
+
+func newPlayScreen() *playScreen {
+	return &playScreen{view: container.NewStack()}
+}
+
+//------------------------------------------------------------------------
+// render
+//------------------------------------------------------------------------
+// Replaces the screen's contents, stopping any countdown that was shown
+
+func (s *playScreen) render(content func() fyne.CanvasObject) {
+	if s.countdown != nil {
+		s.countdown.stop()
+		s.countdown = nil
+	}
+	s.view.Objects = []fyne.CanvasObject{content()}
+	s.view.Refresh()
+}
+
+// Shows a countdown following the game's clock (if it's been set), which
+// is stopped once the screen is next rendered
+
+func (s *playScreen) clock(game *logic.Game) []fyne.CanvasObject {
+	now := time.Now()
+	if _, length := game.Clock(now); length == 0 {
+		return nil
+	}
+	s.countdown = newCountdown(game)
+
+	status := centeredLabel("")
+	if game.ClockExpired(now) {
+		status.SetText("Time's up!")
+	}
+	return []fyne.CanvasObject{s.countdown.bar, status}
+}
+
+//------------------------------------------------------------------------
+// runBoard
+//------------------------------------------------------------------------
+// Opens the audience and host windows to play the current board in
+
+func runBoard(win fyne.Window) {
+	board := logic.GetCurrBoard()
+	if board == nil {
+		dialog.ShowInformation("No Board", "Create or open a board to play", win)
+		return
+	}
+	preflight(win, board, func() {
+		runGame(logic.NewGame(board))
+	})
+}
+
+//------------------------------------------------------------------------
+// runGame
+//------------------------------------------------------------------------
+// Opens the audience and host windows for a game. Both are drawn from the
+// same game, so they always agree. The game is autosaved as it's played,
+// so that it can be resumed if the app crashes
+
+func runGame(game *logic.Game) {
+	board := game.Board()
+	autosave := func() {
+		game.ScheduleAutosave(func(err error) {
+			log.Println("Couldn't autosave the game:", err)
+		})
+	}
+	game.OnBoardChange(func() {
+		if board == logic.GetCurrBoard() {
+			logic.BoardChange()
+		}
+	})
+	game.OnChange(autosave)
+	autosave()
+
+	audienceWin := fyne.CurrentApp().NewWindow(board.Name)
+	hostWin := fyne.CurrentApp().NewWindow(board.Name + " - Host")
+	for _, playWin := range []fyne.Window{audienceWin, hostWin} {
+		addKeysToWindow(playWin, buzzerKeys(game)...)
+	}
+
+	audience := newPlayScreen()
+	host := newPlayScreen()
+	refresh := func() {
+		audience.render(func() fyne.CanvasObject {
+			return audienceContent(game, audience)
+		})
+		host.render(func() fyne.CanvasObject {
+			return hostContent(game, host, hostWin)
+		})
+	}
+	game.OnChange(refresh)
+	refresh()
+
+	// Closing either window ends the game
+	ended := false
+	endGame := func(other fyne.Window) func() {
+		return func() {
+			if ended {
+				return
+			}
+			ended = true
+			game.End()
+			logic.ClearGameAutosave()
+			removeAudio()
+			other.Close()
+		}
+	}
+	audienceWin.SetOnClosed(endGame(hostWin))
+	hostWin.SetOnClosed(endGame(audienceWin))
+
+	audienceWin.SetContent(audience.view)
+	audienceWin.Resize(fyne.NewSize(1000, 600))
+	audienceWin.Show()
+
+	hostWin.SetContent(host.view)
+	hostWin.Resize(fyne.NewSize(900, 600))
+	hostWin.Show()
+}
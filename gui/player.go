@@ -0,0 +1,305 @@
+//========================================================================
+// player.go
+//========================================================================
+// An interface for editing the players of a board
+//
+// Author: Aidan McNay
+// Date: June 11th, 2024
+
+package gui
+
+import (
+	"errors"
+	"fmt"
+	"jeopardy/logic"
+	"jeopardy/style"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/validation"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// playerExists
+//------------------------------------------------------------------------
+// Checks whether a player name already exists
+
+func playerExists(name string) error {
+	board := logic.GetCurrBoard()
+	if board == nil {
+		return nil
+	}
+	for _, v := range board.Players {
+		if name == v.GetName() {
+			errorText := fmt.Sprintf("%v already exists", name)
+			return errors.New(errorText)
+		}
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------
+// otherPlayerExists
+//------------------------------------------------------------------------
+// Checks whether a player name already exists, if it's not our original
+// name
+
+func otherPlayerExists(origName string) func(name string) error {
+	return func(name string) error {
+		if name == origName {
+			return nil
+		}
+		return playerExists(name)
+	}
+}
+
+//------------------------------------------------------------------------
+// buzzerKeyOptions
+//------------------------------------------------------------------------
+// Lists the keys that a player could buzz in with, excluding those
+// already taken by other players
+
+const noBuzzerKey = "None"
+
+func buzzerKeyOptions(player *logic.Player) []string {
+	board := logic.GetCurrBoard()
+
+	options := []string{noBuzzerKey}
+	var keys []string = nil
+	for c := 'A'; c <= 'Z'; c++ {
+		keys = append(keys, string(c))
+	}
+	for c := '0'; c <= '9'; c++ {
+		keys = append(keys, string(c))
+	}
+	for _, key := range keys {
+		owner := board.PlayerWithKey(key)
+		if (owner == nil) || (owner == player) {
+			options = append(options, key)
+		}
+	}
+	return options
+}
+
+func buzzerKeySelect(player *logic.Player) *widget.Select {
+	keySelect := widget.NewSelect(buzzerKeyOptions(player), func(string) {})
+	if key := player.GetKey(); key != "" {
+		keySelect.SetSelected(key)
+	} else {
+		keySelect.SetSelected(noBuzzerKey)
+	}
+	return keySelect
+}
+
+func selectedBuzzerKey(keySelect *widget.Select) string {
+	if keySelect.Selected == noBuzzerKey {
+		return ""
+	}
+	return keySelect.Selected
+}
+
+//------------------------------------------------------------------------
+// addPlayer
+//------------------------------------------------------------------------
+// Creates a dialogue to add a new player
+
+func addPlayer(win fyne.Window) {
+	openPopup()
+	newName := widget.NewEntry()
+	newName.Validator = validation.NewAllStrings(
+		validation.NewRegexp(`^.+$`, "Player must have a non-empty name"),
+		playerExists,
+	)
+	newKey := buzzerKeySelect(nil)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Player Name", newName),
+		widget.NewFormItem("Buzzer Key", newKey),
+	}
+	onConfirm := func(b bool) {
+		closePopup()
+		if !b {
+			return
+		}
+		board := logic.GetCurrBoard()
+		newPlayer := logic.MakePlayer(newName.Text)
+		newPlayer.SetKey(selectedBuzzerKey(newKey))
+		logic.Apply(logic.AddPlayerCommand(board, newPlayer))
+	}
+
+	prompt := dialog.NewForm("New Player", "Add Player", "Cancel", items,
+		onConfirm, win)
+
+	var height float32 = prompt.MinSize().Height
+	var width float32 = 400
+	newSize := fyne.NewSize(width, height)
+	prompt.Resize(newSize)
+
+	prompt.Show()
+}
+
+//------------------------------------------------------------------------
+// deletePlayer
+//------------------------------------------------------------------------
+// Creates a dialogue to confirm deletion of a player
+
+func deletePlayer(player *logic.Player,
+	form *dialog.FormDialog,
+	win fyne.Window,
+) {
+	deleteCallback := func(b bool) {
+		if b {
+			curr_board := logic.GetCurrBoard()
+			form.Hide()
+			logic.Apply(logic.RemovePlayerCommand(curr_board, player))
+		}
+	}
+	dialog.ShowConfirm(
+		fmt.Sprintf("Delete %v", player.GetName()),
+		"Are you sure? You can undo this from the Board menu",
+		deleteCallback,
+		win,
+	)
+}
+
+//------------------------------------------------------------------------
+// editPlayer
+//------------------------------------------------------------------------
+// Creates a dialogue to rename or delete a player
+
+func editPlayer(win fyne.Window, player *logic.Player) {
+	openPopup()
+	newName := widget.NewEntry()
+	newName.SetText(player.GetName())
+	newName.Validator = validation.NewAllStrings(
+		validation.NewRegexp(`^.+$`, "Player must have a non-empty name"),
+		otherPlayerExists(player.GetName()),
+	)
+	newKey := buzzerKeySelect(player)
+
+	deleteButton := widget.NewButtonWithIcon("", theme.CancelIcon(),
+		func() {})
+	deleteButton.Importance = widget.DangerImportance
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Player Name", newName),
+		widget.NewFormItem("Buzzer Key", newKey),
+		widget.NewFormItem("Delete Player?", deleteButton),
+	}
+	onConfirm := func(b bool) {
+		closePopup()
+		if !b {
+			return
+		}
+		logic.Apply(logic.EditPlayerCommand(player, newName.Text,
+			selectedBuzzerKey(newKey)))
+	}
+
+	prompt := dialog.NewForm("Edit Player", "Save", "Cancel", items,
+		onConfirm, win)
+	deleteButton.OnTapped = func() {
+		deletePlayer(player, prompt, win)
+	}
+
+	var height float32 = prompt.MinSize().Height
+	var width float32 = 400
+	newSize := fyne.NewSize(width, height)
+	prompt.Resize(newSize)
+
+	prompt.Show()
+}
+
+//------------------------------------------------------------------------
+// resetScores
+//------------------------------------------------------------------------
+// Creates a dialogue to confirm resetting all players' scores
+
+func resetScores(win fyne.Window) {
+	resetCallback := func(b bool) {
+		if b {
+			logic.Apply(logic.ResetScoresCommand(logic.GetCurrBoard()))
+		}
+	}
+	dialog.ShowConfirm(
+		"Reset Scores",
+		"Set every player's score back to 0?",
+		resetCallback,
+		win,
+	)
+}
+
+//------------------------------------------------------------------------
+// playerRow
+//------------------------------------------------------------------------
+// Creates the row for a single player, with buttons to edit them and
+// move them up or down the roster
+
+func playerRow(win fyne.Window, idx int, player *logic.Player) fyne.CanvasObject {
+	board := logic.GetCurrBoard()
+
+	name := widget.NewButton(player.GetName(), func() {
+		editPlayer(win, player)
+	})
+	name.Importance = widget.LowImportance
+
+	score := widget.NewLabel(fmt.Sprintf("%v", player.GetScore()))
+
+	keyText := "No buzzer key"
+	if key := player.GetKey(); key != "" {
+		keyText = fmt.Sprintf("Buzzes with %v", key)
+	}
+	key := widget.NewLabel(keyText)
+	key.TextStyle = fyne.TextStyle{Italic: true}
+
+	upButton := widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() {
+		logic.Apply(logic.SwapPlayersCommand(board, idx, idx-1))
+	})
+	if idx == 0 {
+		upButton.Disable()
+	}
+	downButton := widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() {
+		logic.Apply(logic.SwapPlayersCommand(board, idx, idx+1))
+	})
+	if idx == len(board.Players)-1 {
+		downButton.Disable()
+	}
+
+	return container.NewHBox(upButton, downButton, name, score, key)
+}
+
+//------------------------------------------------------------------------
+// Make a new Players element
+//------------------------------------------------------------------------
+
+func playersGUI(win fyne.Window) fyne.CanvasObject {
+	board := logic.GetCurrBoard()
+
+	var rows []fyne.CanvasObject = nil
+	for idx, v := range board.Players {
+		rows = append(rows, playerRow(win, idx, v))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, widget.NewLabel("No players yet"))
+	}
+
+	addButton := style.NewColorButton(
+		"Add Player",
+		themeColor(style.ColorNameCategory),
+		func() {
+			addPlayer(win)
+		},
+	)
+	resetButton := widget.NewButton("Reset Scores", func() {
+		resetScores(win)
+	})
+	if len(board.Players) == 0 {
+		resetButton.Disable()
+	}
+
+	rows = append(rows, addButton, resetButton, layout.NewSpacer())
+	return container.NewVBox(rows...)
+}
@@ -0,0 +1,146 @@
+//========================================================================
+// problems.go
+//========================================================================
+// Showing problems with the board, both while editing and before it's
+// played
+//
+// Author: Aidan McNay
+// Date: June 23rd, 2024
+
+package gui
+
+import (
+	"fmt"
+	"jeopardy/logic"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// problemsTitle
+//------------------------------------------------------------------------
+// The name of the problems tab, including how many there are
+
+func problemsTitle(problems []logic.Problem) string {
+	if len(problems) == 0 {
+		return "Problems"
+	}
+	return fmt.Sprintf("Problems (%v)", len(problems))
+}
+
+//------------------------------------------------------------------------
+// editProblem
+//------------------------------------------------------------------------
+// Opens the editor for wherever the problem is
+
+func editProblem(win fyne.Window, problem logic.Problem) {
+	board := logic.GetCurrBoard()
+	switch {
+	case problem.Category != nil && problem.Category == board.Final:
+		editFinal(win)
+	case problem.Question != nil:
+		editQuestion(win, problem.Category, problem.Question)
+	case problem.Category != nil:
+		editCategory(win, problem.Round, problem.Category)
+	case problem.Round != nil:
+		editRound(win, problem.Round)
+	}
+}
+
+//------------------------------------------------------------------------
+// problemRow
+//------------------------------------------------------------------------
+
+func problemIcon(problem logic.Problem) fyne.CanvasObject {
+	if problem.Severity == logic.Error {
+		return widget.NewIcon(theme.ErrorIcon())
+	}
+	return widget.NewIcon(theme.WarningIcon())
+}
+
+func problemLabel(problem logic.Problem) *widget.Label {
+	text := problem.Message
+	if location := problem.Location(); location != "" {
+		text = fmt.Sprintf("%v: %v", location, problem.Message)
+	}
+	return widget.NewLabel(text)
+}
+
+func problemRow(win fyne.Window, problem logic.Problem) fyne.CanvasObject {
+	edit := widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
+		editProblem(win, problem)
+	})
+	edit.Importance = widget.LowImportance
+	return container.NewHBox(problemIcon(problem), edit, problemLabel(problem))
+}
+
+//------------------------------------------------------------------------
+// problemsGUI
+//------------------------------------------------------------------------
+// Lists every problem with the current board
+
+func problemsGUI(win fyne.Window, problems []logic.Problem) fyne.CanvasObject {
+	if len(problems) == 0 {
+		return widget.NewLabel("No problems found")
+	}
+	errors, warnings := logic.CountProblems(problems)
+	summary := widget.NewLabel(fmt.Sprintf("%v error(s), %v warning(s)",
+		errors, warnings))
+	summary.TextStyle = fyne.TextStyle{Bold: true}
+
+	rows := []fyne.CanvasObject{summary}
+	for _, problem := range problems {
+		rows = append(rows, problemRow(win, problem))
+	}
+	return container.NewVBox(rows...)
+}
+
+//------------------------------------------------------------------------
+// preflight
+//------------------------------------------------------------------------
+// Checks the board before it's played, letting the user either play
+// anyway or go back and fix any problems
+
+func preflight(win fyne.Window, board *logic.Board, play func()) {
+	problems := board.Validate()
+	if len(problems) == 0 {
+		play()
+		return
+	}
+	if !canOpenPopup() {
+		return
+	}
+	openPopup()
+
+	errors, warnings := logic.CountProblems(problems)
+	message := widget.NewLabel(fmt.Sprintf(
+		"This board has %v error(s) and %v warning(s):", errors, warnings))
+
+	var rows []fyne.CanvasObject = nil
+	for _, problem := range problems {
+		rows = append(rows, container.NewHBox(problemIcon(problem),
+			problemLabel(problem)))
+	}
+	list := container.NewVScroll(container.NewVBox(rows...))
+	list.SetMinSize(fyne.NewSize(0, 200))
+
+	content := container.NewBorder(message, nil, nil, nil, list)
+	confirm := dialog.NewCustomConfirm("Problems Found", "Play Anyway",
+		"Cancel", content, func(b bool) {
+			closePopup()
+			if b {
+				play()
+			}
+		}, win)
+
+	var height float32 = confirm.MinSize().Height
+	var width float32 = 500
+	newSize := fyne.NewSize(width, height)
+	confirm.Resize(newSize)
+
+	confirm.Show()
+}
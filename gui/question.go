@@ -21,6 +21,27 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+//------------------------------------------------------------------------
+// timeLimitEntry
+//------------------------------------------------------------------------
+// Creates an entry for a question's time limit in seconds, which is left
+// blank to use the board's default
+
+func timeLimitEntry(limit int) *widget.Entry {
+	entry := widget.NewEntry()
+	entry.PlaceHolder = "Board default"
+	entry.Validator = func(s string) error {
+		if s == "" {
+			return nil
+		}
+		return isPositiveInt(s)
+	}
+	if limit > 0 {
+		entry.Text = fmt.Sprintf("%v", limit)
+	}
+	return entry
+}
+
 //------------------------------------------------------------------------
 // deleteQuestion
 //------------------------------------------------------------------------
@@ -33,14 +54,13 @@ func deleteQuestion(question *logic.Question,
 ) {
 	deleteCallback := func(b bool) {
 		if b {
-			category.RemoveQuestion(question)
 			form.Hide()
-			logic.BoardChange()
+			logic.Apply(logic.RemoveQuestionCommand(category, question))
 		}
 	}
 	dialog.ShowConfirm(
 		"Delete Question",
-		"Are you sure? This action can't be undone",
+		"Are you sure? You can undo this from the Board menu",
 		deleteCallback,
 		win,
 	)
@@ -67,6 +87,11 @@ func editQuestion(win fyne.Window,
 	newPoints.Validator = isInt
 	newPoints.Text = fmt.Sprintf("%v", question.Points)
 
+	newDailyDouble := widget.NewCheck("", func(bool) {})
+	newDailyDouble.Checked = question.DailyDouble
+
+	newTimeLimit := timeLimitEntry(question.TimeLimit)
+
 	deleteButton := widget.NewButtonWithIcon("", theme.CancelIcon(),
 		func() {})
 	deleteButton.Importance = widget.DangerImportance
@@ -75,16 +100,22 @@ func editQuestion(win fyne.Window,
 		widget.NewFormItem("Prompt", newPrompt),
 		widget.NewFormItem("Answer", newAnswer),
 		widget.NewFormItem("Points", newPoints),
-		widget.NewFormItem("Delete Question?", deleteButton),
+		widget.NewFormItem("Daily Double", newDailyDouble),
+		widget.NewFormItem("Time Limit (s)", newTimeLimit),
 	}
+	mediaItems, applyMedia := mediaFormItems(win, question)
+	items = append(items, mediaItems...)
+	items = append(items, widget.NewFormItem("Delete Question?", deleteButton))
 	onConfirm := func(b bool) {
 		if !b {
 			return
 		}
-		question.Prompt = newPrompt.Text
-		question.Answer = newAnswer.Text
-		question.Points, _ = strconv.Atoi(newPoints.Text)
-		logic.BoardChange()
+		edited := logic.MakeQuestion(newPrompt.Text, newAnswer.Text, 0)
+		edited.Points, _ = strconv.Atoi(newPoints.Text)
+		edited.DailyDouble = newDailyDouble.Checked
+		edited.TimeLimit, _ = strconv.Atoi(newTimeLimit.Text)
+		applyMedia(edited)
+		logic.Apply(logic.EditQuestionCommand(question, edited))
 	}
 
 	formTitle := "Edit Question"
@@ -112,6 +143,9 @@ func questionButton(win fyne.Window,
 	question *logic.Question,
 ) fyne.CanvasObject {
 	displayText := fmt.Sprintf("%v", question.Points)
+	if question.DailyDouble {
+		displayText += " (DD)"
+	}
 	button := widget.NewButton(displayText, func() {
 		editQuestion(win, category, question)
 	})
@@ -0,0 +1,251 @@
+//========================================================================
+// round.go
+//========================================================================
+// An interface for rendering a round in a GUI
+//
+// Author: Aidan McNay
+// Date: June 16th, 2024
+
+package gui
+
+import (
+	"errors"
+	"fmt"
+	"jeopardy/logic"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/validation"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// otherRoundExists
+//------------------------------------------------------------------------
+// Checks whether a round name already exists, if it's not our original
+// name
+
+func otherRoundExists(origName string) func(name string) error {
+	return func(name string) error {
+		board := logic.GetCurrBoard()
+		if board == nil {
+			return nil
+		}
+		for _, v := range board.Rounds {
+			if (name == v.Name) && (name != origName) {
+				errorText := fmt.Sprintf("%v already exists", name)
+				return errors.New(errorText)
+			}
+		}
+		return nil
+	}
+}
+
+//------------------------------------------------------------------------
+// isPositiveInt
+//------------------------------------------------------------------------
+// Determines whether a string represents an integer that's at least 1
+
+func isPositiveInt(s string) error {
+	if err := isInt(s); err != nil {
+		return err
+	}
+	if v, _ := strconv.Atoi(s); v < 1 {
+		return errors.New(s + " must be positive")
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------
+// addRound
+//------------------------------------------------------------------------
+// Creates a dialogue to add a new round
+
+func addRound(win fyne.Window) {
+	openPopup()
+	board := logic.GetCurrBoard()
+
+	newName := widget.NewEntry()
+	newName.Validator = validation.NewAllStrings(
+		validation.NewRegexp(`^.+$`, "Round must have a non-empty name"),
+		otherRoundExists(""),
+	)
+
+	newMultiplier := widget.NewEntry()
+	newMultiplier.Validator = isPositiveInt
+	newMultiplier.Text = fmt.Sprintf("%v", len(board.Rounds)+1)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Round Name", newName),
+		widget.NewFormItem("Point Multiplier", newMultiplier),
+	}
+	onConfirm := func(b bool) {
+		closePopup()
+		if !b {
+			return
+		}
+		newRound := logic.MakeRound(newName.Text)
+		newRound.Multiplier, _ = strconv.Atoi(newMultiplier.Text)
+		selectedTab = len(board.Rounds)
+		logic.Apply(logic.AddRoundCommand(board, newRound))
+	}
+
+	prompt := dialog.NewForm("New Round", "Add Round", "Cancel", items,
+		onConfirm, win)
+
+	var height float32 = prompt.MinSize().Height
+	var width float32 = 400
+	newSize := fyne.NewSize(width, height)
+	prompt.Resize(newSize)
+
+	prompt.Show()
+}
+
+//------------------------------------------------------------------------
+// deleteRound
+//------------------------------------------------------------------------
+// Creates a dialogue to confirm deletion of a round
+
+func deleteRound(round *logic.Round,
+	form *dialog.FormDialog,
+	win fyne.Window,
+) {
+	deleteCallback := func(b bool) {
+		if b {
+			curr_board := logic.GetCurrBoard()
+			form.Hide()
+			logic.Apply(logic.RemoveRoundCommand(curr_board, round))
+		}
+	}
+	dialog.ShowConfirm(
+		fmt.Sprintf("Delete %v", round.Name),
+		"Are you sure? You can undo this from the Board menu",
+		deleteCallback,
+		win,
+	)
+}
+
+//------------------------------------------------------------------------
+// editRound
+//------------------------------------------------------------------------
+// Creates a dialogue to edit the round
+
+func editRound(win fyne.Window, round *logic.Round) {
+	openPopup()
+	board := logic.GetCurrBoard()
+
+	newName := widget.NewEntry()
+	newName.SetText(round.Name)
+	newName.Validator = validation.NewAllStrings(
+		validation.NewRegexp(`^.+$`, "Round must have a non-empty name"),
+		otherRoundExists(round.Name),
+	)
+
+	newMultiplier := widget.NewEntry()
+	newMultiplier.Validator = isPositiveInt
+	newMultiplier.Text = fmt.Sprintf("%v", round.GetMultiplier())
+
+	deleteButton := widget.NewButtonWithIcon("", theme.CancelIcon(),
+		func() {})
+	deleteButton.Importance = widget.DangerImportance
+	if len(board.Rounds) == 1 {
+		// Every board needs at least one round
+		deleteButton.Disable()
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Round Name", newName),
+		widget.NewFormItem("Point Multiplier", newMultiplier),
+		widget.NewFormItem("Delete Round?", deleteButton),
+	}
+	onConfirm := func(b bool) {
+		closePopup()
+		if !b {
+			return
+		}
+		multiplier, _ := strconv.Atoi(newMultiplier.Text)
+		logic.Apply(logic.BatchCommand("Edit Round",
+			logic.SetCommand("", &round.Name, newName.Text),
+			logic.SetCommand("", &round.Multiplier, multiplier),
+		))
+	}
+
+	prompt := dialog.NewForm("Edit Round", "Save", "Cancel", items,
+		onConfirm, win)
+	deleteButton.OnTapped = func() {
+		deleteRound(round, prompt, win)
+	}
+
+	var height float32 = prompt.MinSize().Height
+	var width float32 = 400
+	newSize := fyne.NewSize(width, height)
+	prompt.Resize(newSize)
+
+	prompt.Show()
+}
+
+//------------------------------------------------------------------------
+// roundHeader
+//------------------------------------------------------------------------
+// Creates the buttons to edit, move, and add rounds
+
+func roundHeader(win fyne.Window, idx int, round *logic.Round) fyne.CanvasObject {
+	board := logic.GetCurrBoard()
+
+	leftButton := widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() {
+		selectedTab = idx - 1
+		logic.Apply(logic.SwapRoundsCommand(board, idx, idx-1))
+	})
+	if idx == 0 {
+		leftButton.Disable()
+	}
+	rightButton := widget.NewButtonWithIcon("", theme.NavigateNextIcon(), func() {
+		selectedTab = idx + 1
+		logic.Apply(logic.SwapRoundsCommand(board, idx, idx+1))
+	})
+	if idx == len(board.Rounds)-1 {
+		rightButton.Disable()
+	}
+
+	editButton := widget.NewButtonWithIcon(
+		fmt.Sprintf("%v (x%v points)", round.Name, round.GetMultiplier()),
+		theme.DocumentCreateIcon(),
+		func() {
+			editRound(win, round)
+		},
+	)
+	addButton := widget.NewButtonWithIcon("Add Round", theme.ContentAddIcon(),
+		func() {
+			addRound(win)
+		},
+	)
+
+	return container.NewHBox(leftButton, rightButton, editButton, addButton)
+}
+
+//------------------------------------------------------------------------
+// Make a new Round element
+//------------------------------------------------------------------------
+
+func roundGUI(win fyne.Window, idx int, round *logic.Round) fyne.CanvasObject {
+	var columns []fyne.CanvasObject = nil
+	for categoryIdx, v := range round.Categories {
+		if categoryIdx != 0 {
+			columns = append(columns,
+				addSwapper(round, categoryIdx, categoryIdx-1))
+		}
+		columns = append(columns, categoryGUI(win, round, v))
+	}
+	columns = append(columns, addCategoryButton(win, round))
+	gridLayout := container.NewHBox(columns...)
+
+	return container.NewVBox(
+		roundHeader(win, idx, round),
+		gridLayout,
+		layout.NewSpacer(),
+	)
+}
@@ -0,0 +1,185 @@
+//========================================================================
+// score.go
+//========================================================================
+// An interface for the host to keep score while playing a board
+//
+// Author: Aidan McNay
+// Date: June 13th, 2024
+
+package gui
+
+import (
+	"fmt"
+	"jeopardy/logic"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// describeScoreEvent
+//------------------------------------------------------------------------
+// A human-readable description of a change in score
+
+func describeScoreEvent(board *logic.Board, event logic.ScoreEvent) string {
+	var reason string
+	switch event.Question {
+	case nil:
+		reason = "manual override"
+	case board.FinalQuestion():
+		reason = "Final Jeopardy"
+	default:
+		// The question may have since been removed from the board
+		reason = fmt.Sprintf("%v points", event.Question.Points)
+		if category := board.CategoryOf(event.Question); category != nil {
+			reason = fmt.Sprintf("%v for %v", category.Name, event.Question.Points)
+		}
+	}
+	return fmt.Sprintf("%v  %v %+d (%v)",
+		event.Time.Format("15:04:05"),
+		event.Player.GetName(),
+		event.Delta,
+		reason,
+	)
+}
+
+//------------------------------------------------------------------------
+// showScoreHistory
+//------------------------------------------------------------------------
+// Shows a dialog listing every change in score so far
+
+func showScoreHistory(board *logic.Board,
+	history *logic.ScoreHistory,
+	win fyne.Window,
+) {
+	var rows []fyne.CanvasObject = nil
+	for _, event := range history.Events() {
+		rows = append(rows, widget.NewLabel(describeScoreEvent(board, event)))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, widget.NewLabel("No scores have changed yet"))
+	}
+
+	scroll := container.NewVScroll(container.NewVBox(rows...))
+	scroll.SetMinSize(fyne.NewSize(400, 300))
+	dialog.ShowCustom("Score History", "Close", scroll, win)
+}
+
+//------------------------------------------------------------------------
+// overrideScore
+//------------------------------------------------------------------------
+// Creates a dialogue to manually set a player's score
+
+func overrideScore(player *logic.Player, game *logic.Game, win fyne.Window) {
+	newScore := widget.NewEntry()
+	newScore.Validator = isInt
+	newScore.Text = fmt.Sprintf("%v", player.GetScore())
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Score", newScore),
+	}
+	onConfirm := func(b bool) {
+		if !b {
+			return
+		}
+		score, _ := strconv.Atoi(newScore.Text)
+		game.OverrideScore(player, score)
+	}
+
+	formTitle := fmt.Sprintf("Set Score for %v", player.GetName())
+	prompt := dialog.NewForm(formTitle, "Save", "Cancel", items,
+		onConfirm, win)
+
+	var height float32 = prompt.MinSize().Height
+	var width float32 = 300
+	newSize := fyne.NewSize(width, height)
+	prompt.Resize(newSize)
+
+	prompt.Show()
+}
+
+//------------------------------------------------------------------------
+// scoresTitle
+//------------------------------------------------------------------------
+
+func scoresTitle() fyne.CanvasObject {
+	title := centeredLabel("Scores")
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	return title
+}
+
+//------------------------------------------------------------------------
+// hostScores
+//------------------------------------------------------------------------
+// Shows each player's score, and lets the host mark the current question
+// as answered correctly or incorrectly
+
+func hostScores(game *logic.Game, win fyne.Window) fyne.CanvasObject {
+	board := game.Board()
+	history := game.History()
+
+	rows := []fyne.CanvasObject{scoresTitle()}
+	for _, v := range board.Players {
+		player := v
+		name := widget.NewLabel(player.GetName())
+		name.TextStyle = fyne.TextStyle{Bold: true}
+
+		score := widget.NewButton(fmt.Sprintf("%v", player.GetScore()), func() {
+			overrideScore(player, game, win)
+		})
+		score.Importance = widget.LowImportance
+
+		correctButton := widget.NewButtonWithIcon("", theme.ConfirmIcon(), func() {
+			game.Score(player, true)
+		})
+		correctButton.Importance = widget.SuccessImportance
+		incorrectButton := widget.NewButtonWithIcon("", theme.CancelIcon(), func() {
+			game.Score(player, false)
+		})
+		incorrectButton.Importance = widget.DangerImportance
+		if !game.CanScore(player) {
+			correctButton.Disable()
+			incorrectButton.Disable()
+		}
+
+		rows = append(rows, container.NewBorder(nil, nil, name,
+			container.NewHBox(score, correctButton, incorrectButton)))
+	}
+	if len(board.Players) == 0 {
+		rows = append(rows, widget.NewLabel("Add players in the editor"))
+	}
+
+	undoButton := widget.NewButtonWithIcon("Undo", theme.ContentUndoIcon(),
+		game.UndoScore)
+	if len(history.Events()) == 0 {
+		undoButton.Disable()
+	}
+	historyButton := widget.NewButtonWithIcon("History", theme.HistoryIcon(), func() {
+		showScoreHistory(board, history, win)
+	})
+	rows = append(rows, layout.NewSpacer(),
+		container.NewGridWithColumns(2, undoButton, historyButton))
+
+	return container.NewVBox(rows...)
+}
+
+//------------------------------------------------------------------------
+// audienceScores
+//------------------------------------------------------------------------
+// Shows each player's score, without any of the host's controls
+
+func audienceScores(game *logic.Game) fyne.CanvasObject {
+	rows := []fyne.CanvasObject{scoresTitle()}
+	for _, v := range game.Board().Players {
+		name := widget.NewLabel(v.GetName())
+		name.TextStyle = fyne.TextStyle{Bold: true}
+		score := widget.NewLabel(fmt.Sprintf("%v", v.GetScore()))
+		rows = append(rows, container.NewBorder(nil, nil, name, score))
+	}
+	return container.NewVBox(rows...)
+}
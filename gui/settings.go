@@ -0,0 +1,111 @@
+//========================================================================
+// settings.go
+//========================================================================
+// An interface for editing how a board is played
+//
+// Author: Aidan McNay
+// Date: June 12th, 2024
+
+package gui
+
+import (
+	"errors"
+	"fmt"
+	"jeopardy/logic"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// isNonNegativeInt
+//------------------------------------------------------------------------
+// Determines whether a string represents an integer that's at least 0
+
+func isNonNegativeInt(s string) error {
+	if err := isInt(s); err != nil {
+		return err
+	}
+	if v, _ := strconv.Atoi(s); v < 0 {
+		return errors.New(s + " must not be negative")
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------
+// editSettings
+//------------------------------------------------------------------------
+// Creates a dialogue to edit the board's settings
+
+func editSettings(win fyne.Window) {
+	openPopup()
+	settings := logic.GetCurrBoard().GetSettings()
+
+	newLockout := widget.NewEntry()
+	newLockout.Validator = isNonNegativeInt
+	newLockout.Text = fmt.Sprintf("%v", settings.LockoutMillis)
+
+	newFinal := widget.NewEntry()
+	newFinal.Validator = isNonNegativeInt
+	newFinal.Text = fmt.Sprintf("%v", settings.FinalSeconds)
+
+	newQuestion := widget.NewEntry()
+	newQuestion.Validator = isNonNegativeInt
+	newQuestion.Text = fmt.Sprintf("%v", settings.QuestionSeconds)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Early Buzz Lockout (ms)", newLockout),
+		widget.NewFormItem("Final Jeopardy Time (s)", newFinal),
+		widget.NewFormItem("Question Time (s, 0 for none)", newQuestion),
+	}
+	onConfirm := func(b bool) {
+		closePopup()
+		if !b {
+			return
+		}
+		updated := *settings
+		updated.LockoutMillis, _ = strconv.Atoi(newLockout.Text)
+		updated.FinalSeconds, _ = strconv.Atoi(newFinal.Text)
+		updated.QuestionSeconds, _ = strconv.Atoi(newQuestion.Text)
+		logic.Apply(logic.SetCommand("Edit Settings", settings, updated))
+	}
+
+	prompt := dialog.NewForm("Edit Settings", "Save", "Cancel", items,
+		onConfirm, win)
+
+	var height float32 = prompt.MinSize().Height
+	var width float32 = 400
+	newSize := fyne.NewSize(width, height)
+	prompt.Resize(newSize)
+
+	prompt.Show()
+}
+
+//------------------------------------------------------------------------
+// Make a new Settings element
+//------------------------------------------------------------------------
+
+func settingsGUI(win fyne.Window) fyne.CanvasObject {
+	settings := logic.GetCurrBoard().GetSettings()
+
+	questionTime := "No limit"
+	if settings.QuestionSeconds > 0 {
+		questionTime = fmt.Sprintf("%v s", settings.QuestionSeconds)
+	}
+
+	form := widget.NewForm(
+		widget.NewFormItem("Early Buzz Lockout",
+			widget.NewLabel(fmt.Sprintf("%v ms", settings.LockoutMillis))),
+		widget.NewFormItem("Final Jeopardy Time",
+			widget.NewLabel(fmt.Sprintf("%v s", settings.FinalSeconds))),
+		widget.NewFormItem("Question Time", widget.NewLabel(questionTime)),
+	)
+	editButton := widget.NewButton("Edit Settings", func() {
+		editSettings(win)
+	})
+	return container.NewVBox(form, editButton, layout.NewSpacer())
+}
@@ -9,18 +9,239 @@
 package gui
 
 import (
+	"image/color"
+	"jeopardy/logic"
+	"jeopardy/style"
+
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// Radio options for the background of a style
+//------------------------------------------------------------------------
+
+const (
+	useColorOption = "Color"
+	useImageOption = "Image"
 )
 
+//------------------------------------------------------------------------
+// usesThemeColor
+//------------------------------------------------------------------------
+// A fully transparent color (the default) means that a style should
+// defer to the editor's theme
+
+func usesThemeColor(c color.Color) bool {
+	if c == nil {
+		return true
+	}
+	_, _, _, a := c.RGBA()
+	return a == 0
+}
+
+//------------------------------------------------------------------------
+// styledTile
+//------------------------------------------------------------------------
+// Creates a tile drawn with the given style, using the fallback theme
+// color if the style doesn't specify one
+
+func styledTile(s *logic.Style,
+	fallback fyne.ThemeColorName,
+	text string,
+	textSize float32,
+	onTap func(),
+) fyne.CanvasObject {
+	useImage := !s.UseColor && (s.Image != nil)
+
+	var fillColor color.Color
+	var textColor color.Color
+	switch {
+	case useImage:
+		fillColor = color.Transparent
+		textColor = s.TextColor
+	case usesThemeColor(s.Color):
+		fillColor = themeColor(fallback)
+		textColor = nil
+	default:
+		fillColor = s.Color
+		textColor = s.TextColor
+	}
+
+	button := style.NewColorButton(text, fillColor, onTap)
+	button.TextColor = textColor
+	if textSize > 0 {
+		button.TextSize = textSize
+	}
+	if !useImage {
+		return button
+	}
+
+	image := canvas.NewImageFromResource(s.Image)
+	image.FillMode = canvas.ImageFillStretch
+	return container.NewStack(image, button)
+}
+
+//------------------------------------------------------------------------
+// chooseImage
+//------------------------------------------------------------------------
+// Opens a file dialog to pick a new background image for a style
+
+func chooseImage(s *logic.Style, callback func(), win fyne.Window) {
+	fd := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if reader == nil {
+			// Cancelled
+			return
+		}
+		reader.Close()
+
+		image, err := logic.ResourceFromURI(reader.URI())
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		s.Image = image
+		callback()
+	}, win)
+	fd.SetFilter(storage.NewExtensionFileFilter(
+		[]string{".png", ".jpg", ".jpeg", ".svg"},
+	))
+	fd.Show()
+}
+
+//------------------------------------------------------------------------
+// styleEditor
+//------------------------------------------------------------------------
+// Creates the controls for editing a single style in-place, along with a
+// preview of how it'll look
+
+func styleEditor(s *logic.Style,
+	fallback fyne.ThemeColorName,
+	sample string,
+	win fyne.Window,
+) fyne.CanvasObject {
+	preview := container.NewGridWrap(fyne.NewSize(200, 80))
+	imageName := widget.NewLabel("")
+
+	colorButton := style.NewColorButton("Background", color.Transparent, func() {})
+	textColorButton := style.NewColorButton("Text", color.Transparent, func() {})
+
+	update := func() {
+		if usesThemeColor(s.Color) {
+			colorButton.SetColor(themeColor(fallback))
+		} else {
+			colorButton.SetColor(s.Color)
+		}
+		textColorButton.SetColor(s.TextColor)
+
+		if s.Image == nil {
+			imageName.SetText("No image")
+		} else {
+			imageName.SetText(s.Image.Name())
+		}
+
+		preview.RemoveAll()
+		preview.Add(styledTile(s, fallback, sample, 20, func() {}))
+	}
+
+	colorButton.OnTapped(func() {
+		if usesThemeColor(s.Color) {
+			s.Color = themeColor(fallback)
+		}
+		style.OpenColorPrompt(&s.Color, update, win)
+	})
+	textColorButton.OnTapped(func() {
+		style.OpenColorPrompt(&s.TextColor, update, win)
+	})
+	imageButton := widget.NewButton("Choose Image...", func() {
+		chooseImage(s, update, win)
+	})
+	var background *widget.RadioGroup
+	resetButton := widget.NewButton("Use Theme Colors", func() {
+		*s = *logic.NewStyle()
+		background.SetSelected(useColorOption)
+		update()
+	})
+
+	background = widget.NewRadioGroup(
+		[]string{useColorOption, useImageOption},
+		func(option string) {
+			s.UseColor = (option != useImageOption)
+			update()
+		},
+	)
+	background.Horizontal = true
+	background.Required = true
+	if s.UseColor {
+		background.SetSelected(useColorOption)
+	} else {
+		background.SetSelected(useImageOption)
+	}
+	update()
+
+	form := widget.NewForm(
+		widget.NewFormItem("Background", background),
+		widget.NewFormItem("Color", colorButton),
+		widget.NewFormItem("Image", container.NewHBox(imageButton, imageName)),
+		widget.NewFormItem("Text Color", textColorButton),
+		widget.NewFormItem("", resetButton),
+	)
+	return container.NewVBox(form, container.NewCenter(preview))
+}
+
 //------------------------------------------------------------------------
 // Main style GUI
 //------------------------------------------------------------------------
 
 func styleGUI(win fyne.Window) {
+	board := logic.GetCurrBoard()
+	if board == nil {
+		return
+	}
+	if !canOpenPopup() {
+		return
+	}
 	openPopup()
 
-	dialog.ShowConfirm("Style Editor", "Placeholder", func(b bool) {
+	// Edit copies, so that cancelling leaves the board untouched
+	gameStyle := board.GetStyle()
+	categoryStyle := *gameStyle.CategoryStyle
+	questionStyle := *gameStyle.QuestionStyle
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem("Categories",
+			styleEditor(&categoryStyle, style.ColorNameCategory, "Category", win)),
+		container.NewTabItem("Questions",
+			styleEditor(&questionStyle, style.ColorNameQuestion, "200", win)),
+	)
+
+	onConfirm := func(b bool) {
 		closePopup()
-	}, win)
+		if !b {
+			return
+		}
+		updated := logic.GameStyle{
+			CategoryStyle: &categoryStyle,
+			QuestionStyle: &questionStyle,
+		}
+		logic.Apply(logic.SetCommand("Edit Style", gameStyle, updated))
+	}
+
+	prompt := dialog.NewCustomConfirm("Style Editor", "Save", "Cancel", tabs,
+		onConfirm, win)
+
+	var height float32 = prompt.MinSize().Height
+	var width float32 = 500
+	newSize := fyne.NewSize(width, height)
+	prompt.Resize(newSize)
+
+	prompt.Show()
 }
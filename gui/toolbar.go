@@ -9,12 +9,13 @@
 package gui
 
 import (
+	"fmt"
 	"image/color"
 	"jeopardy/assets"
 	"jeopardy/logic"
 	"jeopardy/style"
-	"log"
 	"net/url"
+	"os"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -41,10 +42,15 @@ func closePopup()        { canCreatePopup = true }
 // New Board Creation
 //------------------------------------------------------------------------
 
-var currURI fyne.URI = nil
 var haveABoard = false
 
 func promptNewBoard(win fyne.Window) {
+	confirmDiscard(win, func() {
+		showNewBoardPrompt(win)
+	})
+}
+
+func showNewBoardPrompt(win fyne.Window) {
 	if !canOpenPopup() {
 		return
 	}
@@ -61,7 +67,6 @@ func promptNewBoard(win fyne.Window) {
 		if !b {
 			return
 		}
-		currURI = nil
 		haveABoard = true
 		logic.NewBoard(newName.Text)
 	}
@@ -81,6 +86,12 @@ func promptNewBoard(win fyne.Window) {
 //------------------------------------------------------------------------
 
 func loadFromFile(win fyne.Window) {
+	confirmDiscard(win, func() {
+		showLoadPrompt(win)
+	})
+}
+
+func showLoadPrompt(win fyne.Window) {
 	if !canOpenPopup() {
 		return
 	}
@@ -97,22 +108,48 @@ func loadFromFile(win fyne.Window) {
 			return
 		}
 
-		currURI = reader.URI()
+		if err := logic.LoadCurrBoard(reader); err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
 		haveABoard = true
-		logic.LoadCurrBoard(reader)
+		logic.Redraw()
 	}, win)
 	fd.SetFilter(storage.NewExtensionFileFilter([]string{".jpdy"}))
 	fd.Show()
 }
 
 func saveToFile(win fyne.Window, forceSaveAs bool) {
+	saveThen(win, forceSaveAs, nil)
+}
+
+// The save dialog creates the file it's given, which isn't needed if the
+// board gets saved with a different extension
+
+func removeIfEmpty(uri fyne.URI) {
+	if uri.Scheme() != "file" {
+		return
+	}
+	if info, err := os.Stat(uri.Path()); err == nil && info.Size() == 0 {
+		os.Remove(uri.Path())
+	}
+}
+
+// Saves the board, calling onSaved (if not nil) once it's been saved
+
+func saveThen(win fyne.Window, forceSaveAs bool, onSaved func()) {
 	if !haveABoard {
 		// No board to save
 		return
 	}
-	if (currURI != nil) && !forceSaveAs {
-		writer, _ := storage.Writer(currURI)
-		logic.SaveCurrBoard(writer)
+	if currURI := logic.GetCurrURI(); (currURI != nil) && !forceSaveAs {
+		if _, err := logic.SaveCurrBoard(currURI); err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if onSaved != nil {
+			onSaved()
+		}
 		return
 	}
 	if !canOpenPopup() {
@@ -131,13 +168,97 @@ func saveToFile(win fyne.Window, forceSaveAs bool) {
 			return
 		}
 
-		logic.SaveCurrBoard(writer)
-		currURI = writer.URI()
+		// The board is saved separately (rather than through the dialog's
+		// writer), so that it can be written safely
+		chosen := writer.URI()
+		writer.Close()
+		uri, err := logic.SaveCurrBoard(chosen)
+		if uri != nil && uri.String() != chosen.String() {
+			removeIfEmpty(chosen)
+		}
+		if err != nil {
+			dialog.ShowError(err, win)
+			return
+		}
+		if onSaved != nil {
+			onSaved()
+		}
 	}, win)
 	fd.SetFilter(storage.NewExtensionFileFilter([]string{".jpdy"}))
 	fd.Show()
 }
 
+//------------------------------------------------------------------------
+// Unsaved Changes
+//------------------------------------------------------------------------
+
+// Names the current board in the window's title, marking it with an
+// asterisk if it has unsaved changes
+
+func UpdateTitle(win fyne.Window) {
+	title := "Jeopardy Editor"
+	if board := logic.GetCurrBoard(); haveABoard && (board != nil) {
+		title += " - " + board.Name
+		if logic.IsDirty() {
+			title += "*"
+		}
+	}
+	win.SetTitle(title)
+}
+
+// Asks whether to save any unsaved changes before they would be lost,
+// calling proceed unless the user cancels
+
+func confirmDiscard(win fyne.Window, proceed func()) {
+	if !haveABoard || !logic.IsDirty() {
+		proceed()
+		return
+	}
+	if !canOpenPopup() {
+		return
+	}
+	openPopup()
+
+	message := widget.NewLabel(fmt.Sprintf(
+		"%v has unsaved changes. Save them before continuing?",
+		logic.GetCurrBoard().Name,
+	))
+	prompt := dialog.NewCustomWithoutButtons("Unsaved Changes", message, win)
+
+	saveButton := widget.NewButton("Save", func() {
+		prompt.Hide()
+		closePopup()
+		saveThen(win, false, proceed)
+	})
+	saveButton.Importance = widget.HighImportance
+	discardButton := widget.NewButton("Discard", func() {
+		prompt.Hide()
+		closePopup()
+		logic.ClearBoardAutosave()
+		proceed()
+	})
+	discardButton.Importance = widget.DangerImportance
+	cancelButton := widget.NewButton("Cancel", func() {
+		prompt.Hide()
+		closePopup()
+	})
+	prompt.SetButtons([]fyne.CanvasObject{cancelButton, discardButton, saveButton})
+
+	prompt.Show()
+}
+
+// Checks for unsaved changes before the window is closed. Closing it
+// quits the app, ending any game being played
+
+func InterceptClose(win fyne.Window) {
+	win.SetCloseIntercept(func() {
+		confirmDiscard(win, func() {
+			logic.ClearGameAutosave()
+			win.Close()
+		})
+	})
+}
+
 //------------------------------------------------------------------------
 // Help Window
 //------------------------------------------------------------------------
@@ -206,7 +327,7 @@ func Toolbar(win fyne.Window) *widget.Toolbar {
 		styleGUI(win)
 	})
 	runBoardAction := widget.NewToolbarAction(theme.MediaPlayIcon(), func() {
-		log.Println("Play clicked")
+		runBoard(win)
 	})
 	otherThemeAction := widget.NewToolbarAction(theme.SettingsIcon(), func() {})
 	settingsAction := widget.NewToolbarAction(theme.SettingsIcon(), func() {
@@ -242,7 +363,7 @@ func Toolbar(win fyne.Window) *widget.Toolbar {
 			style.SetVariant(theme.VariantDark)
 		}
 		refreshIcons()
-		logic.BoardChange()
+		logic.Redraw()
 		style.StoreColorPreferences(fyne.CurrentApp())
 	}
 
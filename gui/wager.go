@@ -0,0 +1,97 @@
+//========================================================================
+// wager.go
+//========================================================================
+// An interface for players to wager on a Daily Double
+//
+// Author: Aidan McNay
+// Date: June 14th, 2024
+
+package gui
+
+import (
+	"errors"
+	"fmt"
+	"jeopardy/logic"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+//------------------------------------------------------------------------
+// playerNamed
+//------------------------------------------------------------------------
+// Finds the player with the given name, or nil if there isn't one
+
+func playerNamed(board *logic.Board, name string) *logic.Player {
+	for _, v := range board.Players {
+		if v.GetName() == name {
+			return v
+		}
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------
+// promptWager
+//------------------------------------------------------------------------
+// Creates a dialogue for choosing who found a Daily Double and how much
+// they wager on it, calling onWager once a valid wager is made. Wagers may
+// go up to the round's most valuable question
+
+func promptWager(board *logic.Board,
+	round *logic.Round,
+	win fyne.Window,
+	onWager func(player *logic.Player, wager int),
+) {
+	var names []string = nil
+	for _, v := range board.Players {
+		names = append(names, v.GetName())
+	}
+	newPlayer := widget.NewSelect(names, func(string) {})
+
+	newWager := widget.NewEntry()
+	newWager.Validator = func(s string) error {
+		player := playerNamed(board, newPlayer.Selected)
+		if player == nil {
+			return errors.New("choose who found the Daily Double")
+		}
+		if err := isInt(s); err != nil {
+			return err
+		}
+		wager, _ := strconv.Atoi(s)
+		return logic.ValidateWager(wager, player.GetScore(), round.MaxValue())
+	}
+
+	limits := widget.NewLabel("")
+	newPlayer.OnChanged = func(name string) {
+		player := playerNamed(board, name)
+		limits.SetText(fmt.Sprintf("%v to %v", logic.MinWager,
+			logic.MaxWager(player.GetScore(), round.MaxValue())))
+		newWager.Validate()
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Player", newPlayer),
+		widget.NewFormItem("Wager", newWager),
+		widget.NewFormItem("Allowed", limits),
+	}
+	onConfirm := func(b bool) {
+		if !b {
+			return
+		}
+		wager, _ := strconv.Atoi(newWager.Text)
+		onWager(playerNamed(board, newPlayer.Selected), wager)
+	}
+
+	prompt := dialog.NewForm("Daily Double!", "Wager", "Cancel", items,
+		onConfirm, win)
+
+	var height float32 = prompt.MinSize().Height
+	var width float32 = 400
+	newSize := fyne.NewSize(width, height)
+	prompt.Resize(newSize)
+
+	prompt.Show()
+}
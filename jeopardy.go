@@ -2,9 +2,11 @@ package main
 
 import (
 	"jeopardy/assets"
+	"jeopardy/cli"
 	"jeopardy/gui"
 	"jeopardy/logic"
 	"jeopardy/style"
+	"os"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
@@ -12,11 +14,17 @@ import (
 )
 
 func main() {
+	// Subcommands work on files without opening the editor
+	if cli.Handles(os.Args[1:]) {
+		os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	myApp := app.NewWithID("github.com.Aidan-McNay.jeopardy")
 	myApp.SetIcon(assets.ResourceLogoPng)
 
 	myWindow := myApp.NewWindow("Jeopardy Editor")
 	style.InitTheme(myApp)
+	gui.InitBackups(myApp)
 
 	toolbar := gui.Toolbar(myWindow)
 	boardEditor := gui.BoardGUI(myWindow)
@@ -24,6 +32,7 @@ func main() {
 	content := container.NewBorder(toolbar, nil, nil, nil, boardEditor)
 	logic.OnBoardChange(func(board *logic.Board) {
 		gui.UpdateBoard(boardEditor, myWindow)
+		gui.UpdateTitle(myWindow)
 		content.Refresh()
 	})
 	myWindow.SetContent(content)
@@ -33,5 +42,9 @@ func main() {
 
 	myWindow.Resize(fyne.NewSize(1000, 600))
 	myWindow.SetMaster()
+	gui.InterceptClose(myWindow)
+
+	gui.StartAutosave(myApp)
+	gui.OfferRecovery(myWindow)
 	myWindow.ShowAndRun()
 }
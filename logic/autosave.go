@@ -0,0 +1,443 @@
+//========================================================================
+// autosave.go
+//========================================================================
+// Periodic copies of the current board and any game being played, so
+// that work can be recovered after a crash
+//
+// Author: Aidan McNay
+// Date: June 19th, 2024
+
+package logic
+
+import (
+	"encoding/json"
+	"jeopardy/file"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+)
+
+//------------------------------------------------------------------------
+// Define the Autosave Types
+//------------------------------------------------------------------------
+// URI is where the board was last saved by the user, if anywhere. Games
+// are saved along with their board, which holds the scores and answered
+// questions
+
+type boardAutosave struct {
+	Board *Board
+	URI   string
+	Time  time.Time
+}
+
+type gameAutosave struct {
+	Board *Board
+	URI   string
+	State gameState
+	Time  time.Time
+}
+
+//------------------------------------------------------------------------
+// Autosave Locations
+//------------------------------------------------------------------------
+
+var autosaveRoot fyne.URI = nil
+
+const boardAutosaveName = "autosave.jpdy"
+const gameAutosaveName = "autosave-game.jpdy"
+
+// SetAutosaveRoot chooses the directory to autosave in (usually the app's
+// storage root). Nothing is autosaved until it's set
+
+func SetAutosaveRoot(root fyne.URI) {
+	autosaveRoot = root
+}
+
+func autosaveURI(name string) (fyne.URI, error) {
+	return storage.Child(autosaveRoot, name)
+}
+
+func writeAutosave(name string, v interface{}) error {
+	if autosaveRoot == nil {
+		return nil
+	}
+	uri, err := autosaveURI(name)
+	if err != nil {
+		return err
+	}
+	return writeURI(uri, v, false)
+}
+
+func readAutosave(name string, v interface{}) bool {
+	if autosaveRoot == nil {
+		return false
+	}
+	uri, err := autosaveURI(name)
+	if err != nil {
+		return false
+	}
+	reader, err := storage.Reader(uri)
+	if err != nil {
+		return false
+	}
+	return file.Load(reader, v) == nil
+}
+
+func clearAutosave(name string) {
+	if autosaveRoot == nil {
+		return
+	}
+	if uri, err := autosaveURI(name); err == nil {
+		storage.Delete(uri)
+	}
+}
+
+//------------------------------------------------------------------------
+// Autosaving the Current Board
+//------------------------------------------------------------------------
+// Only unsaved changes are autosaved, and only if there have been more
+// since the last autosave. The lock is held throughout, so that the board
+// can't be changed while it's written
+
+var changes, autosavedChanges int
+
+func AutosaveBoard() error {
+	boardLock.Lock()
+	defer boardLock.Unlock()
+	if !dirty || currBoard == nil || changes == autosavedChanges {
+		return nil
+	}
+	saved := boardAutosave{currBoard, "", time.Now()}
+	if currURI != nil {
+		saved.URI = currURI.String()
+	}
+	if err := writeAutosave(boardAutosaveName, saved); err != nil {
+		return err
+	}
+	autosavedChanges = changes
+	return nil
+}
+
+// Returns the autosaved board, where it was last saved by the user (or
+// nil if it never was), and when it was autosaved
+
+func LoadBoardAutosave() (*Board, fyne.URI, time.Time, bool) {
+	var saved boardAutosave
+	if !readAutosave(boardAutosaveName, &saved) || saved.Board == nil {
+		return nil, nil, time.Time{}, false
+	}
+	var uri fyne.URI = nil
+	if saved.URI != "" {
+		uri, _ = storage.ParseURI(saved.URI)
+	}
+	return saved.Board, uri, saved.Time, true
+}
+
+func ClearBoardAutosave() {
+	boardLock.Lock()
+	defer boardLock.Unlock()
+	autosavedChanges = changes
+	clearAutosave(boardAutosaveName)
+}
+
+// RestoreBoard makes a recovered board the current one, as if it had been
+// loaded from uri. Its changes haven't been saved by the user, so it
+// starts out dirty
+
+func RestoreBoard(board *Board, uri fyne.URI) {
+	replaceBoard(board, uri, true)
+}
+
+//------------------------------------------------------------------------
+// Saving a Game's State
+//------------------------------------------------------------------------
+// Questions and players are saved by where they are on the board, as the
+// board is saved alongside. Final Jeopardy's question is marked as Final,
+// and anything that isn't on the board has an index of -1
+
+type questionRef struct {
+	Final                     bool
+	Round, Category, Question int
+}
+
+type savedScoreEvent struct {
+	Player   int
+	Question questionRef
+	Delta    int
+	Time     time.Time
+}
+
+type savedBuzzer struct {
+	State    BuzzerState
+	Winner   int
+	Order    []int
+	Excluded []int
+}
+
+type gameState struct {
+	Phase        GamePhase
+	Round        int
+	Question     questionRef
+	Value        int
+	Wagerer      int
+	FinalPlayers []int
+	Wagers       map[int]int
+	Responder    int
+	WagerShown   bool
+	ClockLength  time.Duration
+	ClockLeft    time.Duration
+	ClockRunning bool
+	Scores       []savedScoreEvent
+	Buzzer       savedBuzzer
+}
+
+func (b *Board) refOf(question *Question) questionRef {
+	if (question != nil) && (question == b.FinalQuestion()) {
+		return questionRef{true, 0, 0, 0}
+	}
+	for r, round := range b.Rounds {
+		for c, category := range round.Categories {
+			if q := indexOf(category.Questions, question); q >= 0 {
+				return questionRef{false, r, c, q}
+			}
+		}
+	}
+	return questionRef{false, -1, -1, -1}
+}
+
+func (b *Board) questionAt(ref questionRef) (*Category, *Question) {
+	if ref.Final {
+		if q := b.FinalQuestion(); q != nil {
+			return b.Final, q
+		}
+		return nil, nil
+	}
+	if ref.Round < 0 || ref.Round >= len(b.Rounds) {
+		return nil, nil
+	}
+	round := b.Rounds[ref.Round]
+	if ref.Category < 0 || ref.Category >= len(round.Categories) {
+		return nil, nil
+	}
+	category := round.Categories[ref.Category]
+	if ref.Question < 0 || ref.Question >= len(category.Questions) {
+		return nil, nil
+	}
+	return category, category.Questions[ref.Question]
+}
+
+func (b *Board) playerAt(idx int) *Player {
+	if idx < 0 || idx >= len(b.Players) {
+		return nil
+	}
+	return b.Players[idx]
+}
+
+func (b *Board) playerIndices(players [](*Player)) []int {
+	indices := []int{}
+	for _, p := range players {
+		indices = append(indices, indexOf(b.Players, p))
+	}
+	return indices
+}
+
+func (b *Board) playersAt(indices []int) [](*Player) {
+	var players [](*Player) = nil
+	for _, idx := range indices {
+		if p := b.playerAt(idx); p != nil {
+			players = append(players, p)
+		}
+	}
+	return players
+}
+
+// Must be called with the game's lock held
+
+func (g *Game) state() gameState {
+	b := g.board
+	s := gameState{
+		Phase:        g.phase,
+		Round:        g.round,
+		Question:     b.refOf(g.question),
+		Value:        g.value,
+		Wagerer:      indexOf(b.Players, g.wagerer),
+		FinalPlayers: b.playerIndices(g.finalPlayers),
+		Wagers:       map[int]int{},
+		Responder:    g.responder,
+		WagerShown:   g.wagerShown,
+		ClockLength:  g.clockLength,
+		ClockLeft:    g.remaining(time.Now()),
+		ClockRunning: g.clockRunning,
+		Scores:       []savedScoreEvent{},
+	}
+	for player, wager := range g.wagers {
+		if idx := indexOf(b.Players, player); idx >= 0 {
+			s.Wagers[idx] = wager
+		}
+	}
+	for _, e := range g.history.Events() {
+		if idx := indexOf(b.Players, e.Player); idx >= 0 {
+			s.Scores = append(s.Scores,
+				savedScoreEvent{idx, b.refOf(e.Question), e.Delta, e.Time})
+		}
+	}
+
+	g.buzzer.mutex.Lock()
+	excluded := [](*Player){}
+	for player, ok := range g.buzzer.excluded {
+		if ok {
+			excluded = append(excluded, player)
+		}
+	}
+	s.Buzzer = savedBuzzer{
+		g.buzzer.state,
+		indexOf(b.Players, g.buzzer.winner),
+		b.playerIndices(g.buzzer.order),
+		b.playerIndices(excluded),
+	}
+	g.buzzer.mutex.Unlock()
+	return s
+}
+
+// resumeGame continues a game on the board it was saved with. Scores are
+// already on the board, so the history is restored without changing them.
+// Early buzzes have long since stopped locking anyone out
+
+func resumeGame(board *Board, s gameState) *Game {
+	g := NewGame(board)
+	if s.Round >= 0 && s.Round < len(board.Rounds) {
+		g.round = s.Round
+	}
+	for _, e := range s.Scores {
+		if p := board.playerAt(e.Player); p != nil {
+			_, q := board.questionAt(e.Question)
+			g.history.events = append(g.history.events,
+				ScoreEvent{p, q, e.Delta, e.Time})
+		}
+	}
+	if s.Phase == PhaseBoard || s.Phase == PhaseRoundOver {
+		g.phase = s.Phase
+		return g
+	}
+
+	// Otherwise, a question is being played
+	g.category, g.question = board.questionAt(s.Question)
+	if g.question == nil {
+		// It's no longer on the board, so go back to the board
+		g.category = nil
+		return g
+	}
+	g.phase = s.Phase
+	g.value = s.Value
+	g.wagerer = board.playerAt(s.Wagerer)
+	g.finalPlayers = board.playersAt(s.FinalPlayers)
+	for idx, wager := range s.Wagers {
+		if p := board.playerAt(idx); p != nil {
+			g.wagers[p] = wager
+		}
+	}
+	g.responder = min(max(s.Responder, 0), len(g.finalPlayers))
+	g.wagerShown = s.WagerShown
+
+	b := g.buzzer
+	b.state = s.Buzzer.State
+	b.winner = board.playerAt(s.Buzzer.Winner)
+	b.order = board.playersAt(s.Buzzer.Order)
+	for _, p := range board.playersAt(s.Buzzer.Excluded) {
+		b.excluded[p] = true
+	}
+
+	g.clockLength = s.ClockLength
+	g.clockLeft = s.ClockLeft
+	if s.ClockRunning {
+		g.startClock()
+	}
+	return g
+}
+
+//------------------------------------------------------------------------
+// Autosaving Games
+//------------------------------------------------------------------------
+// The game is only held still while it's encoded, and is written after.
+// The board is only given a URI if it's the current board, as otherwise
+// it was never saved by the user
+
+func AutosaveGame(g *Game) error {
+	g.saving.Lock()
+	defer g.saving.Unlock()
+
+	g.mutex.Lock()
+	if g.ended {
+		g.mutex.Unlock()
+		return nil
+	}
+	boardLock.Lock()
+	saved := gameAutosave{g.board, "", g.state(), time.Now()}
+	if (g.board == currBoard) && (currURI != nil) {
+		saved.URI = currURI.String()
+	}
+	content, err := json.Marshal(saved)
+	boardLock.Unlock()
+	g.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+	return writeAutosave(gameAutosaveName, json.RawMessage(content))
+}
+
+// Games change with every buzz, so rather than autosaving each change as
+// it's made, ScheduleAutosave saves the game shortly after (along with
+// any other changes made in the meantime) in the background
+
+const gameAutosaveDelay = time.Second
+
+func (g *Game) ScheduleAutosave(onError func(error)) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.ended || g.saveTimer != nil {
+		return
+	}
+	g.saveTimer = time.AfterFunc(gameAutosaveDelay, func() {
+		g.mutex.Lock()
+		g.saveTimer = nil
+		g.mutex.Unlock()
+		if err := AutosaveGame(g); err != nil {
+			onError(err)
+		}
+	})
+}
+
+// A game that was autosaved, which can be resumed
+
+type SavedGame struct {
+	Board *Board
+	Time  time.Time
+	uri   fyne.URI
+	state gameState
+}
+
+func LoadGameAutosave() (*SavedGame, bool) {
+	var saved gameAutosave
+	if !readAutosave(gameAutosaveName, &saved) || saved.Board == nil {
+		return nil, false
+	}
+	var uri fyne.URI = nil
+	if saved.URI != "" {
+		uri, _ = storage.ParseURI(saved.URI)
+	}
+	return &SavedGame{saved.Board, saved.Time, uri, saved.State}, true
+}
+
+// Resume makes the game's board the current one (so that it's shared with
+// the editor, as when the game started), and continues the game on it
+
+func (s *SavedGame) Resume() *Game {
+	RestoreBoard(s.Board, s.uri)
+	return resumeGame(GetCurrBoard(), s.state)
+}
+
+func ClearGameAutosave() {
+	clearAutosave(gameAutosaveName)
+}
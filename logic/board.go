@@ -8,17 +8,22 @@
 
 package logic
 
-import "reflect"
+import (
+	"encoding/json"
+	"reflect"
+)
 
 //------------------------------------------------------------------------
 // Define a Board Type
 //------------------------------------------------------------------------
 
 type Board struct {
-	Name       string
-	Categories [](*Category)
-	Players    [](*Player)
-	Style      *GameStyle
+	Name     string
+	Rounds   [](*Round)
+	Players  [](*Player)
+	Style    *GameStyle
+	Settings *Settings
+	Final    *Category
 }
 
 //------------------------------------------------------------------------
@@ -26,106 +31,172 @@ type Board struct {
 //------------------------------------------------------------------------
 
 func MakeBoard(name string) *Board {
-	return &Board{name, nil, nil, NewGameStyle()}
+	rounds := [](*Round){MakeRound("Jeopardy")}
+	return &Board{name, rounds, nil, NewGameStyle(), NewSettings(), nil}
 }
 
 //------------------------------------------------------------------------
-// Derived Attributes
+// JSON Unmarshalling
 //------------------------------------------------------------------------
+// Older files are upgraded by the file package before they get here, so
+// this only makes sure that every board has at least one round
 
-func (b *Board) Width() int {
-	if b == nil {
-		return 0
+func (b *Board) UnmarshalJSON(data []byte) error {
+	type plainBoard Board
+	var stored plainBoard
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	*b = Board(stored)
+	if len(b.Rounds) == 0 {
+		b.Rounds = [](*Round){MakeRound("Jeopardy")}
 	}
-	return len(b.Categories)
+	return nil
 }
 
-func (b *Board) Height() int {
+//------------------------------------------------------------------------
+// AddRounds
+//------------------------------------------------------------------------
+// Appends a new round(s)
+
+func (b *Board) AddRounds(rounds ...*Round) {
 	if b == nil {
-		return 0
-	}
-	if len(b.Categories) == 0 {
-		return 0
+		return
 	}
-	curr_height := b.Categories[0].Height()
-	for _, v := range b.Categories {
-		if h := v.Height(); h > curr_height {
-			curr_height = h
+	b.Rounds = append(b.Rounds, rounds...)
+}
+
+//------------------------------------------------------------------------
+// SwapRounds
+//------------------------------------------------------------------------
+// Swaps the rounds at the given indeces
+
+func (b *Board) SwapRounds(idx1, idx2 int) {
+	roundSwapper := reflect.Swapper(b.Rounds)
+	roundSwapper(idx1, idx2)
+}
+
+//------------------------------------------------------------------------
+// RemoveRound
+//------------------------------------------------------------------------
+// Removes the given round by pointer
+
+func (b *Board) RemoveRound(round *Round) {
+	var newRounds [](*Round) = nil
+	for _, v := range b.Rounds {
+		if v != round {
+			newRounds = append(newRounds, v)
 		}
 	}
-	return curr_height
+	b.Rounds = newRounds
 }
 
 //------------------------------------------------------------------------
-// AddCategories
+// AddPlayers
 //------------------------------------------------------------------------
-// Appends a new category(s)
+// Appends a new player(s)
 
-func (b *Board) AddCategories(categories ...*Category) {
+func (b *Board) AddPlayers(players ...*Player) {
 	if b == nil {
 		return
 	}
-	b.Categories = append(b.Categories, categories...)
+	b.Players = append(b.Players, players...)
 }
 
 //------------------------------------------------------------------------
-// SwapCategories
+// SwapPlayers
 //------------------------------------------------------------------------
-// Swaps the categories at the given indeces
+// Swaps the players at the given indeces
 
-func (b *Board) SwapCategories(idx1, idx2 int) {
-	categorySwapper := reflect.Swapper(b.Categories)
-	categorySwapper(idx1, idx2)
+func (b *Board) SwapPlayers(idx1, idx2 int) {
+	playerSwapper := reflect.Swapper(b.Players)
+	playerSwapper(idx1, idx2)
 }
 
 //------------------------------------------------------------------------
-// RemoveCategory
+// RemovePlayer
 //------------------------------------------------------------------------
-// Removes the given category by pointer
+// Removes the given player by pointer
 
-func (b *Board) RemoveCategory(category *Category) {
-	var newCategories [](*Category) = nil
-	for _, v := range b.Categories {
-		if v != category {
-			newCategories = append(newCategories, v)
+func (b *Board) RemovePlayer(player *Player) {
+	var newPlayers [](*Player) = nil
+	for _, v := range b.Players {
+		if v != player {
+			newPlayers = append(newPlayers, v)
 		}
 	}
-	b.Categories = newCategories
+	b.Players = newPlayers
 }
 
 //------------------------------------------------------------------------
-// AddPlayers
+// ResetScores
 //------------------------------------------------------------------------
-// Appends a new player(s)
+// Resets the score of every player to 0
 
-func (b *Board) AddPlayers(players ...*Player) {
+func (b *Board) ResetScores() {
 	if b == nil {
 		return
 	}
-	b.Players = append(b.Players, players...)
+	for _, v := range b.Players {
+		v.ResetScore()
+	}
 }
 
 //------------------------------------------------------------------------
-// MaxPoints
+// PlayerWithKey
 //------------------------------------------------------------------------
-// Returns the maximum points of any category on the board
-//
-// Returns 0 if the board contains no categories or if passed a null
-// pointer
+// Returns the player who buzzes in with the given key, or nil if there
+// isn't one
+
+func (b *Board) PlayerWithKey(key string) *Player {
+	if b == nil || key == "" {
+		return nil
+	}
+	for _, v := range b.Players {
+		if v.GetKey() == key {
+			return v
+		}
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------
+// Questions
+//------------------------------------------------------------------------
+// Returns every question in every round, in order
 
-func (b *Board) MaxPoints() int {
+func (b *Board) Questions() [](*Question) {
 	if b == nil {
-		return 0
+		return nil
+	}
+	var questions [](*Question) = nil
+	for _, round := range b.Rounds {
+		questions = append(questions, round.Questions()...)
 	}
-	if len(b.Categories) == 0 {
-		return 0
-	} else {
-		curr_value := b.Categories[0].MaxPoints()
-		for _, v := range b.Categories {
-			if points := v.MaxPoints(); points > curr_value {
-				curr_value = points
+	return questions
+}
+
+//------------------------------------------------------------------------
+// CategoryOf
+//------------------------------------------------------------------------
+// Returns the category containing the given question, or nil if it isn't
+// on the board
+
+func (b *Board) CategoryOf(question *Question) *Category {
+	if b == nil {
+		return nil
+	}
+	for _, round := range b.Rounds {
+		for _, category := range round.Categories {
+			for _, v := range category.Questions {
+				if v == question {
+					return category
+				}
 			}
 		}
-		return curr_value
 	}
+	if (question != nil) && (question == b.FinalQuestion()) {
+		return b.Final
+	}
+	return nil
 }
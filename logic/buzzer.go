@@ -0,0 +1,214 @@
+//========================================================================
+// buzzer.go
+//========================================================================
+// A representation of the buzzers used to decide who answers a question
+//
+// Author: Aidan McNay
+// Date: June 12th, 2024
+
+package logic
+
+import (
+	"sync"
+	"time"
+)
+
+//------------------------------------------------------------------------
+// Buzzer States
+//------------------------------------------------------------------------
+
+type BuzzerState int
+
+const (
+	BuzzerIdle   BuzzerState = iota // No question is being played
+	BuzzerArmed                     // A question is being read
+	BuzzerOpen                      // Players may buzz in
+	BuzzerLocked                    // A player has buzzed in
+)
+
+//------------------------------------------------------------------------
+// Results of a player buzzing in
+//------------------------------------------------------------------------
+
+type BuzzResult int
+
+const (
+	BuzzIgnored  BuzzResult = iota // The buzz had no effect
+	BuzzEarly                      // The player buzzed too early
+	BuzzAccepted                   // The player gets to answer
+)
+
+//------------------------------------------------------------------------
+// Define a Buzzer Type
+//------------------------------------------------------------------------
+
+type Buzzer struct {
+	state     BuzzerState
+	penalty   time.Duration
+	winner    *Player
+	order     [](*Player)
+	excluded  map[*Player]bool
+	lockedOut map[*Player]time.Time
+	onChange  func()
+	mutex     sync.Mutex
+}
+
+//------------------------------------------------------------------------
+// Provide an allocator for a buzzer
+//------------------------------------------------------------------------
+
+func NewBuzzer(penalty time.Duration) *Buzzer {
+	return &Buzzer{
+		state:     BuzzerIdle,
+		penalty:   penalty,
+		excluded:  make(map[*Player]bool),
+		lockedOut: make(map[*Player]time.Time),
+	}
+}
+
+//------------------------------------------------------------------------
+// OnChange
+//------------------------------------------------------------------------
+// Sets a callback to use whenever the buzzer's state changes
+
+func (b *Buzzer) OnChange(callback func()) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.onChange = callback
+}
+
+// Must be called without the lock held, as callbacks may query us
+
+func (b *Buzzer) changed() {
+	b.mutex.Lock()
+	callback := b.onChange
+	b.mutex.Unlock()
+
+	if callback != nil {
+		callback()
+	}
+}
+
+//------------------------------------------------------------------------
+// Getters
+//------------------------------------------------------------------------
+
+func (b *Buzzer) State() BuzzerState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.state
+}
+
+// The player who buzzed in, or nil if no one currently has
+
+func (b *Buzzer) Winner() *Player {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.winner
+}
+
+// Everyone who has buzzed in on the current question, in order
+
+func (b *Buzzer) Order() [](*Player) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return append([](*Player)(nil), b.order...)
+}
+
+func (b *Buzzer) IsExcluded(player *Player) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.excluded[player]
+}
+
+func (b *Buzzer) IsLockedOut(player *Player, now time.Time) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return now.Before(b.lockedOut[player])
+}
+
+//------------------------------------------------------------------------
+// State Transitions
+//------------------------------------------------------------------------
+// Arm is used when a new question is shown, and Disarm once it's over
+
+func (b *Buzzer) Arm() {
+	b.mutex.Lock()
+	b.state = BuzzerArmed
+	b.winner = nil
+	b.order = nil
+	b.excluded = make(map[*Player]bool)
+	b.lockedOut = make(map[*Player]time.Time)
+	b.mutex.Unlock()
+	b.changed()
+}
+
+func (b *Buzzer) Open() {
+	b.mutex.Lock()
+	if b.state == BuzzerIdle {
+		b.mutex.Unlock()
+		return
+	}
+	b.state = BuzzerOpen
+	b.winner = nil
+	b.mutex.Unlock()
+	b.changed()
+}
+
+// Reopen lets the remaining players buzz in after the current player
+// answers incorrectly
+
+func (b *Buzzer) Reopen() {
+	b.mutex.Lock()
+	if b.winner != nil {
+		b.excluded[b.winner] = true
+	}
+	b.mutex.Unlock()
+	b.Open()
+}
+
+func (b *Buzzer) Disarm() {
+	b.mutex.Lock()
+	b.state = BuzzerIdle
+	b.winner = nil
+	b.mutex.Unlock()
+	b.changed()
+}
+
+//------------------------------------------------------------------------
+// Buzz
+//------------------------------------------------------------------------
+// Handles a player buzzing in at the given time. Players who buzz while
+// the question is still being read are locked out for the penalty
+
+func (b *Buzzer) Buzz(player *Player, now time.Time) BuzzResult {
+	b.mutex.Lock()
+	if player == nil || b.excluded[player] || now.Before(b.lockedOut[player]) {
+		b.mutex.Unlock()
+		return BuzzIgnored
+	}
+
+	var result BuzzResult
+	switch b.state {
+	case BuzzerArmed:
+		b.lockedOut[player] = now.Add(b.penalty)
+		result = BuzzEarly
+	case BuzzerOpen:
+		b.state = BuzzerLocked
+		b.winner = player
+		b.order = append(b.order, player)
+		result = BuzzAccepted
+	default:
+		result = BuzzIgnored
+	}
+	b.mutex.Unlock()
+
+	if result != BuzzIgnored {
+		b.changed()
+	}
+	if result == BuzzEarly {
+		// Let listeners know once the lockout is over
+		time.AfterFunc(b.penalty, b.changed)
+	}
+	return result
+}
@@ -0,0 +1,226 @@
+//========================================================================
+// edits.go
+//========================================================================
+// Commands for each edit that can be made to a board, so that they can be
+// undone
+//
+// Author: Aidan McNay
+// Date: June 18th, 2024
+
+package logic
+
+import "math/rand"
+
+//------------------------------------------------------------------------
+// Slice Helpers
+//------------------------------------------------------------------------
+
+func indexOf[T comparable](s []T, v T) int {
+	for idx, elem := range s {
+		if elem == v {
+			return idx
+		}
+	}
+	return -1
+}
+
+// Returns a new slice with v inserted at idx, leaving s untouched
+
+func insertAt[T any](s []T, idx int, v T) []T {
+	idx = min(max(idx, 0), len(s))
+	inserted := make([]T, 0, len(s)+1)
+	inserted = append(inserted, s[:idx]...)
+	inserted = append(inserted, v)
+	return append(inserted, s[idx:]...)
+}
+
+//------------------------------------------------------------------------
+// Rounds
+//------------------------------------------------------------------------
+
+func AddRoundCommand(b *Board, round *Round) Command {
+	return Command{
+		"Add Round",
+		func() { b.AddRounds(round) },
+		func() { b.RemoveRound(round) },
+	}
+}
+
+func RemoveRoundCommand(b *Board, round *Round) Command {
+	idx := indexOf(b.Rounds, round)
+	return Command{
+		"Delete Round",
+		func() { b.RemoveRound(round) },
+		func() { b.Rounds = insertAt(b.Rounds, idx, round) },
+	}
+}
+
+func SwapRoundsCommand(b *Board, idx1, idx2 int) Command {
+	swap := func() { b.SwapRounds(idx1, idx2) }
+	return Command{"Move Round", swap, swap}
+}
+
+//------------------------------------------------------------------------
+// Categories
+//------------------------------------------------------------------------
+
+func AddCategoryCommand(r *Round, category *Category) Command {
+	return Command{
+		"Add Category",
+		func() { r.AddCategories(category) },
+		func() { r.RemoveCategory(category) },
+	}
+}
+
+func RemoveCategoryCommand(r *Round, category *Category) Command {
+	idx := indexOf(r.Categories, category)
+	return Command{
+		"Delete Category",
+		func() { r.RemoveCategory(category) },
+		func() { r.Categories = insertAt(r.Categories, idx, category) },
+	}
+}
+
+func SwapCategoriesCommand(r *Round, idx1, idx2 int) Command {
+	swap := func() { r.SwapCategories(idx1, idx2) }
+	return Command{"Move Category", swap, swap}
+}
+
+//------------------------------------------------------------------------
+// Questions
+//------------------------------------------------------------------------
+
+func AddQuestionCommand(c *Category, question *Question) Command {
+	return Command{
+		"Add Question",
+		func() { c.AddQuestions(question) },
+		func() { c.RemoveQuestion(question) },
+	}
+}
+
+func RemoveQuestionCommand(c *Category, question *Question) Command {
+	idx := indexOf(c.Questions, question)
+	return Command{
+		"Delete Question",
+		func() { c.RemoveQuestion(question) },
+		func() { c.Questions = insertAt(c.Questions, idx, question) },
+	}
+}
+
+// Copies the fields that can be edited from edited. Whether the question
+// has been answered is left alone, as that changes during play
+
+func EditQuestionCommand(q *Question, edited *Question) Command {
+	return BatchCommand("Edit Question",
+		SetCommand("", &q.Prompt, edited.Prompt),
+		SetCommand("", &q.Answer, edited.Answer),
+		SetCommand("", &q.Points, edited.Points),
+		SetCommand("", &q.DailyDouble, edited.DailyDouble),
+		SetCommand("", &q.TimeLimit, edited.TimeLimit),
+		SetCommand("", &q.PromptImage, edited.PromptImage),
+		SetCommand("", &q.PromptAudio, edited.PromptAudio),
+		SetCommand("", &q.AnswerImage, edited.AnswerImage),
+		SetCommand("", &q.AnswerAudio, edited.AnswerAudio),
+	)
+}
+
+//------------------------------------------------------------------------
+// Players
+//------------------------------------------------------------------------
+
+func AddPlayerCommand(b *Board, player *Player) Command {
+	return Command{
+		"Add Player",
+		func() { b.AddPlayers(player) },
+		func() { b.RemovePlayer(player) },
+	}
+}
+
+func RemovePlayerCommand(b *Board, player *Player) Command {
+	idx := indexOf(b.Players, player)
+	return Command{
+		"Delete Player",
+		func() { b.RemovePlayer(player) },
+		func() { b.Players = insertAt(b.Players, idx, player) },
+	}
+}
+
+// The score is left alone, as that changes during play
+
+func EditPlayerCommand(p *Player, name, key string) Command {
+	return BatchCommand("Edit Player",
+		SetCommand("", &p.name, name),
+		SetCommand("", &p.key, key),
+	)
+}
+
+func SwapPlayersCommand(b *Board, idx1, idx2 int) Command {
+	swap := func() { b.SwapPlayers(idx1, idx2) }
+	return Command{"Move Player", swap, swap}
+}
+
+func ResetScoresCommand(b *Board) Command {
+	var resets []Command = nil
+	for _, v := range b.Players {
+		resets = append(resets, SetCommand("", &v.score, 0))
+	}
+	return BatchCommand("Reset Scores", resets...)
+}
+
+//------------------------------------------------------------------------
+// Final Jeopardy
+//------------------------------------------------------------------------
+
+func SetFinalCommand(b *Board, category, prompt, answer string) Command {
+	final := MakeCategory(category)
+	final.AddQuestions(MakeQuestion(prompt, answer, 0))
+	return SetCommand("Edit Final Jeopardy", &b.Final, final)
+}
+
+func RemoveFinalCommand(b *Board) Command {
+	return SetCommand("Remove Final Jeopardy", &b.Final, nil)
+}
+
+//------------------------------------------------------------------------
+// Daily Doubles
+//------------------------------------------------------------------------
+// Randomly places counts[i] Daily Doubles in the i-th round. The board is
+// left untouched until the command is applied
+
+func PlaceDailyDoublesCommand(b *Board,
+	counts []int,
+	rng *rand.Rand,
+) (Command, error) {
+	questions := b.Questions()
+	before := make([]bool, len(questions))
+	for idx, v := range questions {
+		before[idx] = v.DailyDouble
+	}
+	restore := func() {
+		for idx, v := range questions {
+			v.DailyDouble = before[idx]
+		}
+	}
+
+	for idx, round := range b.Rounds {
+		if idx >= len(counts) {
+			break
+		}
+		if err := round.PlaceDailyDoubles(counts[idx], rng); err != nil {
+			restore()
+			return Command{}, err
+		}
+	}
+
+	after := make([]bool, len(questions))
+	for idx, v := range questions {
+		after[idx] = v.DailyDouble
+	}
+	restore()
+
+	var places []Command = nil
+	for idx, v := range questions {
+		places = append(places, SetCommand("", &v.DailyDouble, after[idx]))
+	}
+	return BatchCommand("Place Daily Doubles", places...), nil
+}
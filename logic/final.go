@@ -0,0 +1,99 @@
+//========================================================================
+// final.go
+//========================================================================
+// Functions for the Final Jeopardy round
+//
+// Final Jeopardy is stored as a category holding a single question, so
+// that it can be scored like any other question
+//
+// Author: Aidan McNay
+// Date: June 15th, 2024
+
+package logic
+
+import "fmt"
+
+//------------------------------------------------------------------------
+// Getters and Setters
+//------------------------------------------------------------------------
+
+// Returns the Final Jeopardy question, or nil if the board has none
+
+func (b *Board) FinalQuestion() *Question {
+	if b == nil || b.Final == nil || len(b.Final.Questions) == 0 {
+		return nil
+	}
+	return b.Final.Questions[0]
+}
+
+func (b *Board) SetFinal(category, prompt, answer string) {
+	if b == nil {
+		return
+	}
+	final := MakeCategory(category)
+	final.AddQuestions(MakeQuestion(prompt, answer, 0))
+	b.Final = final
+}
+
+func (b *Board) RemoveFinal() {
+	if b == nil {
+		return
+	}
+	b.Final = nil
+}
+
+//------------------------------------------------------------------------
+// FinalPlayers
+//------------------------------------------------------------------------
+// Returns the players who can play Final Jeopardy (those with a positive
+// score)
+
+func (b *Board) FinalPlayers() [](*Player) {
+	if b == nil {
+		return nil
+	}
+	var players [](*Player) = nil
+	for _, v := range b.Players {
+		if v.GetScore() > 0 {
+			players = append(players, v)
+		}
+	}
+	return players
+}
+
+//------------------------------------------------------------------------
+// ValidateFinalWager
+//------------------------------------------------------------------------
+// In Final Jeopardy, players may wager anything from nothing to their
+// entire score
+
+func ValidateFinalWager(wager, score int) error {
+	if wager < 0 {
+		return fmt.Errorf("wager can't be negative")
+	}
+	if wager > score {
+		return fmt.Errorf("wager can be at most %v", score)
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------
+// Leaders
+//------------------------------------------------------------------------
+// Returns the player(s) with the highest score
+
+func (b *Board) Leaders() [](*Player) {
+	if b == nil {
+		return nil
+	}
+	var leaders [](*Player) = nil
+	for _, v := range b.Players {
+		switch {
+		case len(leaders) == 0 || v.GetScore() > leaders[0].GetScore():
+			leaders = [](*Player){v}
+		case v.GetScore() == leaders[0].GetScore():
+			leaders = append(leaders, v)
+		}
+	}
+	return leaders
+}
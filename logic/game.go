@@ -0,0 +1,704 @@
+//========================================================================
+// game.go
+//========================================================================
+// The shared state of a board being played, which every play window
+// draws itself from
+//
+// Author: Aidan McNay
+// Date: June 17th, 2024
+
+package logic
+
+import (
+	"sync"
+	"time"
+)
+
+//------------------------------------------------------------------------
+// Game Phases
+//------------------------------------------------------------------------
+
+type GamePhase int
+
+const (
+	PhaseBoard          GamePhase = iota // The host is choosing a question
+	PhaseRoundOver                       // Every question in the round is done
+	PhaseWager                           // A Daily Double awaits its wager
+	PhasePrompt                          // A question's prompt is showing
+	PhaseAnswer                          // A question's answer is showing
+	PhaseFinalCategory                   // Final Jeopardy's category is showing
+	PhaseFinalWagers                     // Final Jeopardy wagers are being made
+	PhaseFinalPrompt                     // Final Jeopardy's prompt is showing
+	PhaseFinalResponses                  // Final Jeopardy responses are judged
+	PhaseFinalWinner                     // The game is over
+)
+
+//------------------------------------------------------------------------
+// Define a Game Type
+//------------------------------------------------------------------------
+// Changes to the board (scores and answered questions) are made with the
+// board's lock held as well, so that they can't race with an autosave
+
+type Game struct {
+	board   *Board
+	buzzer  *Buzzer
+	history *ScoreHistory
+
+	phase    GamePhase
+	round    int
+	category *Category
+	question *Question
+	value    int
+	wagerer  *Player
+
+	finalPlayers [](*Player)
+	wagers       map[*Player]int
+	responder    int
+	wagerShown   bool
+
+	clockLength  time.Duration
+	clockLeft    time.Duration
+	deadline     time.Time
+	clockRunning bool
+	clockTimer   *time.Timer
+
+	listeners      []func()
+	boardListeners []func()
+	mutex          sync.Mutex
+
+	ended     bool
+	saveTimer *time.Timer
+	saving    sync.Mutex // Held while the game is autosaved
+}
+
+//------------------------------------------------------------------------
+// Provide an allocator for a game
+//------------------------------------------------------------------------
+// Play starts from the first round with questions left to play
+
+func NewGame(board *Board) *Game {
+	g := &Game{
+		board:   board,
+		buzzer:  NewBuzzer(board.GetSettings().LockoutPenalty()),
+		history: NewScoreHistory(),
+		phase:   PhaseBoard,
+		wagers:  make(map[*Player]int),
+	}
+	for g.round < len(board.Rounds)-1 && board.Rounds[g.round].IsComplete() {
+		g.round++
+	}
+	g.buzzer.OnChange(g.buzzerChanged)
+	return g
+}
+
+//------------------------------------------------------------------------
+// OnChange
+//------------------------------------------------------------------------
+// Adds a callback to use whenever the game's state changes
+
+func (g *Game) OnChange(callback func()) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.listeners = append(g.listeners, callback)
+}
+
+// Adds a callback to use whenever playing changes the board itself, such
+// as questions being answered or scores changing
+
+func (g *Game) OnBoardChange(callback func()) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.boardListeners = append(g.boardListeners, callback)
+}
+
+// Must be called without the lock held, as callbacks may query us
+
+func (g *Game) changed() {
+	g.mutex.Lock()
+	listeners := append([]func(){}, g.listeners...)
+	g.mutex.Unlock()
+
+	for _, callback := range listeners {
+		callback()
+	}
+}
+
+// A question's clock runs while the buzzers are open, and pauses while a
+// player responds
+
+func (g *Game) buzzerChanged() {
+	state := g.buzzer.State()
+	g.mutex.Lock()
+	if (g.phase == PhasePrompt) && (g.wagerer == nil) {
+		if state == BuzzerOpen {
+			g.startClock()
+		} else {
+			g.stopClock()
+		}
+	}
+	g.mutex.Unlock()
+	g.changed()
+}
+
+func (g *Game) edited() {
+	g.mutex.Lock()
+	listeners := append([]func(){}, g.boardListeners...)
+	g.mutex.Unlock()
+
+	for _, callback := range listeners {
+		callback()
+	}
+	g.changed()
+}
+
+//------------------------------------------------------------------------
+// End
+//------------------------------------------------------------------------
+// Stops the game, after which no more callbacks are made or autosaves
+// written. Any autosave in progress is waited for, so that the autosave
+// can be cleared once the game has ended
+
+func (g *Game) End() {
+	g.mutex.Lock()
+	g.ended = true
+	g.listeners = nil
+	g.boardListeners = nil
+	g.stopClock()
+	if g.saveTimer != nil {
+		g.saveTimer.Stop()
+		g.saveTimer = nil
+	}
+	g.mutex.Unlock()
+	g.buzzer.Disarm()
+
+	g.saving.Lock()
+	g.saving.Unlock()
+}
+
+//------------------------------------------------------------------------
+// Getters
+//------------------------------------------------------------------------
+
+func (g *Game) Board() *Board {
+	return g.board
+}
+
+func (g *Game) Buzzer() *Buzzer {
+	return g.buzzer
+}
+
+func (g *Game) History() *ScoreHistory {
+	return g.history
+}
+
+// A round is only over if there's another one to play, which may not be
+// the case if rounds were deleted in the editor since
+
+func (g *Game) Phase() GamePhase {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.phase == PhaseRoundOver && g.nextRound() == nil {
+		g.phase = PhaseBoard
+	}
+	return g.phase
+}
+
+// The round being played, or nil if the board has no rounds
+
+func (g *Game) Round() *Round {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.currentRound()
+}
+
+// The round after the current one, or nil if this is the last round
+
+func (g *Game) NextRound() *Round {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.nextRound()
+}
+
+// The following must be called with the lock held. Rounds can be added
+// or deleted in the editor during a game, so the current round is moved
+// back onto the board if it's no longer there
+
+func (g *Game) currentRound() *Round {
+	if len(g.board.Rounds) == 0 {
+		g.round = 0
+		return nil
+	}
+	g.round = min(max(g.round, 0), len(g.board.Rounds)-1)
+	return g.board.Rounds[g.round]
+}
+
+func (g *Game) nextRound() *Round {
+	if g.currentRound() == nil || g.round+1 >= len(g.board.Rounds) {
+		return nil
+	}
+	return g.board.Rounds[g.round+1]
+}
+
+// The question being played, and the category it's from
+
+func (g *Game) Question() (*Category, *Question) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.category, g.question
+}
+
+// What the current question is worth (or the Daily Double's wager)
+
+func (g *Game) Value() int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.value
+}
+
+// The player who wagered on the current Daily Double, if any
+
+func (g *Game) Wagerer() *Player {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.wagerer
+}
+
+//------------------------------------------------------------------------
+// Playing Questions
+//------------------------------------------------------------------------
+// SelectQuestion starts playing a question from the board. Daily Doubles
+// wait for a wager (unless there's no one to wager), and all other
+// questions arm the buzzers. Either way, the clock is set for the
+// question's time to respond
+
+func (g *Game) SelectQuestion(category *Category, question *Question) {
+	g.mutex.Lock()
+	round := g.currentRound()
+	if g.phase != PhaseBoard || round == nil || question == nil ||
+		question.Answered {
+		g.mutex.Unlock()
+		return
+	}
+	g.category = category
+	g.question = question
+	g.value = round.Value(question)
+	g.wagerer = nil
+	g.setClock(g.board.GetSettings().QuestionTime(question))
+
+	dailyDouble := question.DailyDouble && len(g.board.Players) > 0
+	if dailyDouble {
+		g.phase = PhaseWager
+	} else {
+		g.phase = PhasePrompt
+	}
+	g.mutex.Unlock()
+
+	if !dailyDouble {
+		g.buzzer.Arm()
+	}
+	g.changed()
+}
+
+// SetWager shows a Daily Double's prompt once a player has wagered on it,
+// starting the clock right away as there are no buzzers
+
+func (g *Game) SetWager(player *Player, wager int) {
+	g.mutex.Lock()
+	if g.phase != PhaseWager {
+		g.mutex.Unlock()
+		return
+	}
+	g.wagerer = player
+	g.value = wager
+	g.phase = PhasePrompt
+	g.startClock()
+	g.mutex.Unlock()
+	g.changed()
+}
+
+// CancelQuestion returns to the board without playing the question
+
+func (g *Game) CancelQuestion() {
+	g.mutex.Lock()
+	if g.phase != PhaseWager && g.phase != PhasePrompt {
+		g.mutex.Unlock()
+		return
+	}
+	g.phase = PhaseBoard
+	g.category = nil
+	g.question = nil
+	g.wagerer = nil
+	g.setClock(0)
+	g.mutex.Unlock()
+
+	g.buzzer.Disarm()
+	g.changed()
+}
+
+func (g *Game) RevealAnswer() {
+	g.mutex.Lock()
+	if g.phase != PhasePrompt {
+		g.mutex.Unlock()
+		return
+	}
+	g.phase = PhaseAnswer
+	g.stopClock()
+	g.mutex.Unlock()
+
+	g.buzzer.Disarm()
+	g.changed()
+}
+
+// ReturnToBoard marks the question as answered, ending the round if it
+// was the last one left
+
+func (g *Game) ReturnToBoard() {
+	g.mutex.Lock()
+	if g.phase != PhaseAnswer {
+		g.mutex.Unlock()
+		return
+	}
+	boardLock.Lock()
+	g.question.SetAnswered()
+	boardLock.Unlock()
+	g.category = nil
+	g.question = nil
+	g.wagerer = nil
+
+	if g.currentRound().IsComplete() && (g.nextRound() != nil) {
+		g.phase = PhaseRoundOver
+	} else {
+		g.phase = PhaseBoard
+	}
+	g.mutex.Unlock()
+	g.edited()
+}
+
+func (g *Game) StartNextRound() {
+	g.mutex.Lock()
+	if g.phase != PhaseRoundOver {
+		g.mutex.Unlock()
+		return
+	}
+	if g.nextRound() != nil {
+		g.round++
+	}
+	g.phase = PhaseBoard
+	g.mutex.Unlock()
+	g.changed()
+}
+
+//------------------------------------------------------------------------
+// Scoring
+//------------------------------------------------------------------------
+// Only the player who wagered can be scored on a Daily Double
+
+func (g *Game) CanScore(player *Player) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.canScore(player)
+}
+
+// Must be called with the lock held
+
+func (g *Game) canScore(player *Player) bool {
+	if g.phase != PhasePrompt && g.phase != PhaseAnswer {
+		return false
+	}
+	return (g.wagerer == nil) || (g.wagerer == player)
+}
+
+// Score adds or subtracts the current question's value from a player
+
+func (g *Game) Score(player *Player, correct bool) {
+	g.mutex.Lock()
+	if !g.canScore(player) {
+		g.mutex.Unlock()
+		return
+	}
+	delta := g.value
+	if !correct {
+		delta = -delta
+	}
+	boardLock.Lock()
+	g.history.Adjust(player, g.question, delta, time.Now())
+	boardLock.Unlock()
+	g.mutex.Unlock()
+	g.edited()
+}
+
+func (g *Game) OverrideScore(player *Player, score int) {
+	g.mutex.Lock()
+	boardLock.Lock()
+	g.history.Override(player, score, time.Now())
+	boardLock.Unlock()
+	g.mutex.Unlock()
+	g.edited()
+}
+
+func (g *Game) UndoScore() {
+	g.mutex.Lock()
+	boardLock.Lock()
+	_, ok := g.history.Undo()
+	boardLock.Unlock()
+	g.mutex.Unlock()
+	if ok {
+		g.edited()
+	}
+}
+
+//------------------------------------------------------------------------
+// Final Jeopardy
+//------------------------------------------------------------------------
+// Final Jeopardy can be started from the board of the last round
+
+func (g *Game) CanStartFinal() bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	final := g.board.FinalQuestion()
+	return (g.phase == PhaseBoard) && (g.nextRound() == nil) &&
+		(final != nil) && !final.Answered
+}
+
+func (g *Game) StartFinal() {
+	if !g.CanStartFinal() {
+		return
+	}
+	g.mutex.Lock()
+	g.phase = PhaseFinalCategory
+	g.question = g.board.FinalQuestion()
+	g.category = g.board.Final
+	g.finalPlayers = g.board.FinalPlayers()
+	g.wagers = make(map[*Player]int)
+	g.mutex.Unlock()
+	g.changed()
+}
+
+// The players who can play Final Jeopardy (those with a positive score)
+
+func (g *Game) FinalPlayers() [](*Player) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.finalPlayers
+}
+
+func (g *Game) CollectFinalWagers() {
+	g.mutex.Lock()
+	if g.phase != PhaseFinalCategory {
+		g.mutex.Unlock()
+		return
+	}
+	g.phase = PhaseFinalWagers
+	g.mutex.Unlock()
+	g.changed()
+}
+
+func (g *Game) SetFinalWager(player *Player, wager int) {
+	g.mutex.Lock()
+	if g.phase != PhaseFinalWagers {
+		g.mutex.Unlock()
+		return
+	}
+	g.wagers[player] = wager
+	g.mutex.Unlock()
+	g.changed()
+}
+
+// The player's wager, and whether they've made one yet
+
+func (g *Game) FinalWager(player *Player) (int, bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	wager, ok := g.wagers[player]
+	return wager, ok
+}
+
+// ShowFinalPrompt reveals the prompt, with a full clock ready to start
+
+func (g *Game) ShowFinalPrompt() {
+	g.mutex.Lock()
+	if g.phase != PhaseFinalCategory && g.phase != PhaseFinalWagers {
+		g.mutex.Unlock()
+		return
+	}
+	g.phase = PhaseFinalPrompt
+	g.setClock(g.board.GetSettings().FinalTime())
+	g.mutex.Unlock()
+	g.changed()
+}
+
+// StartFinalResponses stops the clock, and has the host go through each
+// player's response in turn
+
+func (g *Game) StartFinalResponses() {
+	g.mutex.Lock()
+	if g.phase != PhaseFinalPrompt {
+		g.mutex.Unlock()
+		return
+	}
+	g.stopClock()
+	g.phase = PhaseFinalResponses
+	g.responder = 0
+	g.wagerShown = false
+	g.finishFinalIfDone()
+	g.mutex.Unlock()
+	g.edited()
+}
+
+// The player whose response is being judged, and whether their wager has
+// been revealed yet
+
+func (g *Game) Responder() (*Player, bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.phase != PhaseFinalResponses || g.responder >= len(g.finalPlayers) {
+		return nil, false
+	}
+	return g.finalPlayers[g.responder], g.wagerShown
+}
+
+func (g *Game) RevealFinalWager() {
+	g.mutex.Lock()
+	if g.phase != PhaseFinalResponses {
+		g.mutex.Unlock()
+		return
+	}
+	g.wagerShown = true
+	g.mutex.Unlock()
+	g.changed()
+}
+
+// ScoreFinal judges the current responder, moving on to the next one
+
+func (g *Game) ScoreFinal(correct bool) {
+	g.mutex.Lock()
+	if g.phase != PhaseFinalResponses || !g.wagerShown {
+		g.mutex.Unlock()
+		return
+	}
+	player := g.finalPlayers[g.responder]
+	delta := g.wagers[player]
+	if !correct {
+		delta = -delta
+	}
+	boardLock.Lock()
+	g.history.Adjust(player, g.question, delta, time.Now())
+	boardLock.Unlock()
+	g.responder++
+	g.wagerShown = false
+	g.finishFinalIfDone()
+	g.mutex.Unlock()
+	g.edited()
+}
+
+// Must be called with the lock held
+
+func (g *Game) finishFinalIfDone() {
+	if g.responder < len(g.finalPlayers) {
+		return
+	}
+	boardLock.Lock()
+	g.question.SetAnswered()
+	boardLock.Unlock()
+	g.phase = PhaseFinalWinner
+}
+
+func (g *Game) EndFinal() {
+	g.mutex.Lock()
+	if g.phase != PhaseFinalWinner {
+		g.mutex.Unlock()
+		return
+	}
+	g.phase = PhaseBoard
+	g.category = nil
+	g.question = nil
+	g.mutex.Unlock()
+	g.changed()
+}
+
+//------------------------------------------------------------------------
+// The Clock
+//------------------------------------------------------------------------
+// A countdown for responding, shared by every window. When a question's
+// time runs out, its answer is revealed and the buzzers are locked
+
+func (g *Game) StartClock() {
+	g.mutex.Lock()
+	g.startClock()
+	g.mutex.Unlock()
+	g.changed()
+}
+
+func (g *Game) clockExpired() {
+	g.mutex.Lock()
+	if !g.clockRunning || g.remaining(time.Now()) > 0 {
+		// The clock was stopped or restarted since the timer was set
+		g.mutex.Unlock()
+		return
+	}
+	questionExpired := g.phase == PhasePrompt
+	if questionExpired {
+		g.phase = PhaseAnswer
+	}
+	g.mutex.Unlock()
+
+	if questionExpired {
+		g.buzzer.Disarm()
+	}
+	g.changed()
+}
+
+// Clock returns how much time is left, out of the clock's full length
+
+func (g *Game) Clock(now time.Time) (remaining, length time.Duration) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.remaining(now), g.clockLength
+}
+
+func (g *Game) ClockRunning(now time.Time) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.clockRunning && g.remaining(now) > 0
+}
+
+func (g *Game) ClockExpired(now time.Time) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return (g.clockLength > 0) && (g.remaining(now) <= 0)
+}
+
+// The following must be called with the lock held
+
+func (g *Game) remaining(now time.Time) time.Duration {
+	if !g.clockRunning {
+		return g.clockLeft
+	}
+	return max(g.deadline.Sub(now), 0)
+}
+
+func (g *Game) startClock() {
+	if g.clockRunning || g.clockLeft <= 0 {
+		return
+	}
+	g.clockRunning = true
+	g.deadline = time.Now().Add(g.clockLeft)
+	g.clockTimer = time.AfterFunc(g.clockLeft, g.clockExpired)
+}
+
+func (g *Game) setClock(length time.Duration) {
+	g.stopClock()
+	g.clockLength = length
+	g.clockLeft = length
+}
+
+func (g *Game) stopClock() {
+	if g.clockTimer != nil {
+		g.clockTimer.Stop()
+		g.clockTimer = nil
+	}
+	if g.clockRunning {
+		g.clockLeft = g.remaining(time.Now())
+		g.clockRunning = false
+	}
+}
@@ -0,0 +1,143 @@
+//========================================================================
+// history.go
+//========================================================================
+// A history of edits to a board, which can be undone and redone
+//
+// Author: Aidan McNay
+// Date: June 18th, 2024
+
+package logic
+
+//------------------------------------------------------------------------
+// Define a Command Type
+//------------------------------------------------------------------------
+// A single edit, which knows how to both make and revert itself
+
+type Command struct {
+	Name string
+	Do   func()
+	Undo func()
+}
+
+//------------------------------------------------------------------------
+// SetCommand
+//------------------------------------------------------------------------
+// An edit that replaces the value at ptr, restoring the current value
+// when undone
+
+func SetCommand[T any](name string, ptr *T, value T) Command {
+	old := *ptr
+	return Command{
+		name,
+		func() { *ptr = value },
+		func() { *ptr = old },
+	}
+}
+
+//------------------------------------------------------------------------
+// BatchCommand
+//------------------------------------------------------------------------
+// Combines several edits into one, undoing them in reverse order
+
+func BatchCommand(name string, commands ...Command) Command {
+	return Command{
+		name,
+		func() {
+			for _, c := range commands {
+				c.Do()
+			}
+		},
+		func() {
+			for i := len(commands) - 1; i >= 0; i-- {
+				commands[i].Undo()
+			}
+		},
+	}
+}
+
+//------------------------------------------------------------------------
+// Define a History Type
+//------------------------------------------------------------------------
+
+type History struct {
+	done   []Command
+	undone []Command
+}
+
+//------------------------------------------------------------------------
+// Provide an allocator for a history
+//------------------------------------------------------------------------
+
+func NewHistory() *History {
+	return &History{nil, nil}
+}
+
+//------------------------------------------------------------------------
+// Apply
+//------------------------------------------------------------------------
+// Makes an edit, which can then be undone. Anything that was undone can
+// no longer be redone
+
+func (h *History) Apply(c Command) {
+	c.Do()
+	h.done = append(h.done, c)
+	h.undone = nil
+}
+
+//------------------------------------------------------------------------
+// Undo and Redo
+//------------------------------------------------------------------------
+// Each returns the edit that was undone or redone, and false if there
+// wasn't one
+
+func (h *History) Undo() (Command, bool) {
+	if len(h.done) == 0 {
+		return Command{}, false
+	}
+	c := h.done[len(h.done)-1]
+	h.done = h.done[:len(h.done)-1]
+	c.Undo()
+	h.undone = append(h.undone, c)
+	return c, true
+}
+
+func (h *History) Redo() (Command, bool) {
+	if len(h.undone) == 0 {
+		return Command{}, false
+	}
+	c := h.undone[len(h.undone)-1]
+	h.undone = h.undone[:len(h.undone)-1]
+	c.Do()
+	h.done = append(h.done, c)
+	return c, true
+}
+
+//------------------------------------------------------------------------
+// Getters
+//------------------------------------------------------------------------
+// The names of the edits that would be undone or redone next, or "" if
+// there aren't any
+
+func (h *History) NextUndo() string {
+	if len(h.done) == 0 {
+		return ""
+	}
+	return h.done[len(h.done)-1].Name
+}
+
+func (h *History) NextRedo() string {
+	if len(h.undone) == 0 {
+		return ""
+	}
+	return h.undone[len(h.undone)-1].Name
+}
+
+//------------------------------------------------------------------------
+// Clear
+//------------------------------------------------------------------------
+// Forgets every edit, such as when a different board is opened
+
+func (h *History) Clear() {
+	h.done = nil
+	h.undone = nil
+}
@@ -8,7 +8,10 @@
 
 package logic
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 //------------------------------------------------------------------------
 // Define a Player Type
@@ -17,6 +20,15 @@ import "fmt"
 type Player struct {
 	name  string
 	score int
+	key   string
+}
+
+//------------------------------------------------------------------------
+// Provide an allocator for a player
+//------------------------------------------------------------------------
+
+func MakePlayer(name string) *Player {
+	return &Player{name, 0, ""}
 }
 
 //------------------------------------------------------------------------
@@ -58,6 +70,51 @@ func (p *Player) GetScore() int {
 	return p.score
 }
 
+// The key is the name of the keyboard key used to buzz in (empty if the
+// player doesn't have one)
+
+func (p *Player) GetKey() string {
+	if p == nil {
+		return ""
+	}
+	return p.key
+}
+
+func (p *Player) SetKey(key string) {
+	if p == nil {
+		return
+	}
+	p.key = key
+}
+
+//------------------------------------------------------------------------
+// JSON Marshalling
+//------------------------------------------------------------------------
+// Our fields are unexported (so that they're only changed through the
+// methods above), so we need to explicitly tell encoding/json how to
+// store a player
+
+type playerJSON struct {
+	Name  string
+	Score int
+	Key   string
+}
+
+func (p *Player) MarshalJSON() ([]byte, error) {
+	return json.Marshal(playerJSON{p.name, p.score, p.key})
+}
+
+func (p *Player) UnmarshalJSON(data []byte) error {
+	var stored playerJSON
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	p.name = stored.Name
+	p.score = stored.Score
+	p.key = stored.Key
+	return nil
+}
+
 //------------------------------------------------------------------------
 // AsString
 //------------------------------------------------------------------------
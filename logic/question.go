@@ -8,14 +8,24 @@
 
 package logic
 
+import "fyne.io/fyne/v2"
+
 //------------------------------------------------------------------------
 // Define a Question Type
 //------------------------------------------------------------------------
+// The prompt and answer may each have an image and audio attached, which
+// are stored in the board (like a style's image) and are nil if unused
 
 type Question struct {
 	Prompt, Answer string
 	Points         int
 	Answered       bool
+	DailyDouble    bool
+	TimeLimit      int // Seconds to respond, or 0 to use the board's default
+	PromptImage    *fyne.StaticResource
+	PromptAudio    *fyne.StaticResource
+	AnswerImage    *fyne.StaticResource
+	AnswerAudio    *fyne.StaticResource
 }
 
 //------------------------------------------------------------------------
@@ -23,7 +33,7 @@ type Question struct {
 //------------------------------------------------------------------------
 
 func MakeQuestion(prompt, answer string, points int) *Question {
-	return &Question{prompt, answer, points, false}
+	return &Question{prompt, answer, points, false, false, 0, nil, nil, nil, nil}
 }
 
 //------------------------------------------------------------------------
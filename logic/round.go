@@ -0,0 +1,196 @@
+//========================================================================
+// round.go
+//========================================================================
+// A representation of a single round of Jeopardy
+//
+// Author: Aidan McNay
+// Date: June 16th, 2024
+
+package logic
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+)
+
+//------------------------------------------------------------------------
+// Define a Round Type
+//------------------------------------------------------------------------
+// Every question's points are scaled by the round's multiplier when
+// played (such as doubling them for Double Jeopardy)
+
+type Round struct {
+	Name       string
+	Categories [](*Category)
+	Multiplier int
+}
+
+//------------------------------------------------------------------------
+// Provide an allocator for a round
+//------------------------------------------------------------------------
+
+func MakeRound(name string) *Round {
+	return &Round{name, nil, 1}
+}
+
+//------------------------------------------------------------------------
+// Derived Attributes
+//------------------------------------------------------------------------
+
+func (r *Round) Width() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.Categories)
+}
+
+func (r *Round) Height() int {
+	if r == nil {
+		return 0
+	}
+	if len(r.Categories) == 0 {
+		return 0
+	}
+	curr_height := r.Categories[0].Height()
+	for _, v := range r.Categories {
+		if h := v.Height(); h > curr_height {
+			curr_height = h
+		}
+	}
+	return curr_height
+}
+
+// A missing (zero) multiplier is treated as 1
+
+func (r *Round) GetMultiplier() int {
+	if r == nil || r.Multiplier == 0 {
+		return 1
+	}
+	return r.Multiplier
+}
+
+// Returns what a question is worth when played in this round
+
+func (r *Round) Value(question *Question) int {
+	return question.GetPoints() * r.GetMultiplier()
+}
+
+//------------------------------------------------------------------------
+// AddCategories
+//------------------------------------------------------------------------
+// Appends a new category(s)
+
+func (r *Round) AddCategories(categories ...*Category) {
+	if r == nil {
+		return
+	}
+	r.Categories = append(r.Categories, categories...)
+}
+
+//------------------------------------------------------------------------
+// SwapCategories
+//------------------------------------------------------------------------
+// Swaps the categories at the given indeces
+
+func (r *Round) SwapCategories(idx1, idx2 int) {
+	categorySwapper := reflect.Swapper(r.Categories)
+	categorySwapper(idx1, idx2)
+}
+
+//------------------------------------------------------------------------
+// RemoveCategory
+//------------------------------------------------------------------------
+// Removes the given category by pointer
+
+func (r *Round) RemoveCategory(category *Category) {
+	var newCategories [](*Category) = nil
+	for _, v := range r.Categories {
+		if v != category {
+			newCategories = append(newCategories, v)
+		}
+	}
+	r.Categories = newCategories
+}
+
+//------------------------------------------------------------------------
+// Questions
+//------------------------------------------------------------------------
+// Returns every question in the round, in column order
+
+func (r *Round) Questions() [](*Question) {
+	if r == nil {
+		return nil
+	}
+	var questions [](*Question) = nil
+	for _, category := range r.Categories {
+		questions = append(questions, category.Questions...)
+	}
+	return questions
+}
+
+//------------------------------------------------------------------------
+// IsComplete
+//------------------------------------------------------------------------
+// Whether every question in the round has been answered
+
+func (r *Round) IsComplete() bool {
+	for _, v := range r.Questions() {
+		if !v.Answered {
+			return false
+		}
+	}
+	return true
+}
+
+//------------------------------------------------------------------------
+// MaxPoints
+//------------------------------------------------------------------------
+// Returns the maximum points of any category in the round
+//
+// Returns 0 if the round contains no categories or if passed a null
+// pointer
+
+func (r *Round) MaxPoints() int {
+	if r == nil {
+		return 0
+	}
+	if len(r.Categories) == 0 {
+		return 0
+	} else {
+		curr_value := r.Categories[0].MaxPoints()
+		for _, v := range r.Categories {
+			if points := v.MaxPoints(); points > curr_value {
+				curr_value = points
+			}
+		}
+		return curr_value
+	}
+}
+
+// Returns the most that any question is worth when played in this round
+
+func (r *Round) MaxValue() int {
+	return r.MaxPoints() * r.GetMultiplier()
+}
+
+//------------------------------------------------------------------------
+// PlaceDailyDoubles
+//------------------------------------------------------------------------
+// Clears any existing Daily Doubles, and marks n randomly-chosen
+// questions in the round as Daily Doubles instead
+
+func (r *Round) PlaceDailyDoubles(n int, rng *rand.Rand) error {
+	questions := r.Questions()
+	if n < 0 || n > len(questions) {
+		return fmt.Errorf("can't place %v Daily Doubles in %v, which has %v questions",
+			n, r.Name, len(questions))
+	}
+	for _, v := range questions {
+		v.DailyDouble = false
+	}
+	for _, idx := range rng.Perm(len(questions))[:n] {
+		questions[idx].DailyDouble = true
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+//========================================================================
+// score.go
+//========================================================================
+// A record of every change to the players' scores during a game
+//
+// Author: Aidan McNay
+// Date: June 13th, 2024
+
+package logic
+
+import "time"
+
+//------------------------------------------------------------------------
+// Define a ScoreEvent Type
+//------------------------------------------------------------------------
+// Question is nil for changes that aren't tied to a question, such as
+// the host manually overriding a score
+
+type ScoreEvent struct {
+	Player   *Player
+	Question *Question
+	Delta    int
+	Time     time.Time
+}
+
+//------------------------------------------------------------------------
+// Define a ScoreHistory Type
+//------------------------------------------------------------------------
+
+type ScoreHistory struct {
+	events []ScoreEvent
+}
+
+//------------------------------------------------------------------------
+// Provide an allocator for a history
+//------------------------------------------------------------------------
+
+func NewScoreHistory() *ScoreHistory {
+	return &ScoreHistory{nil}
+}
+
+//------------------------------------------------------------------------
+// Adjust
+//------------------------------------------------------------------------
+// Changes a player's score by delta, recording the change
+
+func (h *ScoreHistory) Adjust(player *Player,
+	question *Question,
+	delta int,
+	now time.Time,
+) {
+	if h == nil || player == nil {
+		return
+	}
+	player.IncrScore(delta)
+	h.events = append(h.events, ScoreEvent{player, question, delta, now})
+}
+
+//------------------------------------------------------------------------
+// Override
+//------------------------------------------------------------------------
+// Sets a player's score directly, recording the change
+
+func (h *ScoreHistory) Override(player *Player, score int, now time.Time) {
+	h.Adjust(player, nil, score-player.GetScore(), now)
+}
+
+//------------------------------------------------------------------------
+// Undo
+//------------------------------------------------------------------------
+// Reverts the most recent change, returning it (and false if there were
+// no changes to undo)
+
+func (h *ScoreHistory) Undo() (ScoreEvent, bool) {
+	if h == nil || len(h.events) == 0 {
+		return ScoreEvent{}, false
+	}
+	last := h.events[len(h.events)-1]
+	h.events = h.events[:len(h.events)-1]
+	last.Player.IncrScore(-last.Delta)
+	return last, true
+}
+
+//------------------------------------------------------------------------
+// Events
+//------------------------------------------------------------------------
+// Returns all recorded changes, oldest first
+
+func (h *ScoreHistory) Events() []ScoreEvent {
+	if h == nil {
+		return nil
+	}
+	return h.events
+}
@@ -0,0 +1,98 @@
+//========================================================================
+// settings.go
+//========================================================================
+// Settings for how a board is played
+//
+// Author: Aidan McNay
+// Date: June 12th, 2024
+
+package logic
+
+import (
+	"encoding/json"
+	"time"
+)
+
+//------------------------------------------------------------------------
+// Define a Settings Type
+//------------------------------------------------------------------------
+
+type Settings struct {
+	LockoutMillis   int // Penalty for buzzing in before buzzers are open
+	FinalSeconds    int // Time to respond in Final Jeopardy
+	QuestionSeconds int // Default time to respond to a question (0 for none)
+}
+
+//------------------------------------------------------------------------
+// Define an allocator with the default settings
+//------------------------------------------------------------------------
+
+func NewSettings() *Settings {
+	return &Settings{
+		LockoutMillis:   250,
+		FinalSeconds:    30,
+		QuestionSeconds: 10,
+	}
+}
+
+//------------------------------------------------------------------------
+// JSON Unmarshalling
+//------------------------------------------------------------------------
+// Start from the defaults, so that settings missing from older files
+// keep their default values
+
+func (s *Settings) UnmarshalJSON(data []byte) error {
+	type plainSettings Settings
+	stored := plainSettings(*NewSettings())
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	*s = Settings(stored)
+	return nil
+}
+
+//------------------------------------------------------------------------
+// Derived Attributes
+//------------------------------------------------------------------------
+
+func (s *Settings) LockoutPenalty() time.Duration {
+	if s == nil {
+		return 0
+	}
+	return time.Duration(s.LockoutMillis) * time.Millisecond
+}
+
+func (s *Settings) FinalTime() time.Duration {
+	if s == nil {
+		return 0
+	}
+	return time.Duration(s.FinalSeconds) * time.Second
+}
+
+// Questions may override the default time to respond
+
+func (s *Settings) QuestionTime(question *Question) time.Duration {
+	if question != nil && question.TimeLimit > 0 {
+		return time.Duration(question.TimeLimit) * time.Second
+	}
+	if s == nil {
+		return 0
+	}
+	return time.Duration(s.QuestionSeconds) * time.Second
+}
+
+//------------------------------------------------------------------------
+// GetSettings
+//------------------------------------------------------------------------
+// Returns the board's settings, using the defaults if they're missing
+// (such as from files that were saved without them)
+
+func (b *Board) GetSettings() *Settings {
+	if b == nil {
+		return NewSettings()
+	}
+	if b.Settings == nil {
+		b.Settings = NewSettings()
+	}
+	return b.Settings
+}
@@ -9,8 +9,9 @@
 package logic
 
 import (
+	"fmt"
 	"jeopardy/file"
-	"log"
+	"sync"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/storage"
@@ -19,8 +20,13 @@ import (
 //------------------------------------------------------------------------
 // Current Board
 //------------------------------------------------------------------------
+// The board is also read by the autosave in the background, so it (along
+// with where it was saved and whether it has unsaved changes) is only
+// changed with boardLock held
 
 var currBoard *Board
+var currURI fyne.URI = nil
+var boardLock sync.Mutex
 
 //------------------------------------------------------------------------
 // Callbacks to use when the board is changed
@@ -32,12 +38,36 @@ func OnBoardChange(callback func(board *Board)) {
 	callbacks = append(callbacks, callback)
 }
 
+// BoardChange is used whenever the board has been modified, whereas
+// Redraw is used when the board only needs to be shown again (such as
+// after a change of theme)
+
 func BoardChange() {
+	boardLock.Lock()
+	dirty = true
+	changes++
+	boardLock.Unlock()
+	Redraw()
+}
+
+func Redraw() {
 	for _, c := range callbacks {
 		c(currBoard)
 	}
 }
 
+//------------------------------------------------------------------------
+// Unsaved Changes
+//------------------------------------------------------------------------
+
+var dirty bool = false
+
+func IsDirty() bool {
+	boardLock.Lock()
+	defer boardLock.Unlock()
+	return dirty
+}
+
 //------------------------------------------------------------------------
 // Getters and Setters
 //------------------------------------------------------------------------
@@ -46,50 +76,153 @@ func GetCurrBoard() *Board {
 	return currBoard
 }
 
+// Where the current board was last loaded from or saved to, or nil if it
+// hasn't been saved yet
+
+func GetCurrURI() fyne.URI {
+	boardLock.Lock()
+	defer boardLock.Unlock()
+	return currURI
+}
+
 func SetCurrBoard(new_board *Board) {
-	currBoard = new_board
+	replaceBoard(new_board, nil, false)
+}
+
+// Must be called without the lock held
+
+func replaceBoard(board *Board, uri fyne.URI, isDirty bool) {
+	boardLock.Lock()
+	currBoard = board
+	currURI = uri
+	history.Clear()
+	dirty = isDirty
+	boardLock.Unlock()
+	if !isDirty {
+		ClearBoardAutosave()
+	}
+	Redraw()
+}
+
+//------------------------------------------------------------------------
+// Edit History
+//------------------------------------------------------------------------
+// Edits to the current board are made through Apply, so that they can be
+// undone and redone
+
+var history = NewHistory()
+
+func GetHistory() *History {
+	return history
+}
+
+func Apply(c Command) {
+	boardLock.Lock()
+	history.Apply(c)
+	boardLock.Unlock()
 	BoardChange()
 }
 
+func Undo() {
+	boardLock.Lock()
+	_, ok := history.Undo()
+	boardLock.Unlock()
+	if ok {
+		BoardChange()
+	}
+}
+
+func Redo() {
+	boardLock.Lock()
+	_, ok := history.Redo()
+	boardLock.Unlock()
+	if ok {
+		BoardChange()
+	}
+}
+
 //------------------------------------------------------------------------
-// Changes a URIWriteCloser to use the correct extension
+// Makes sure a URI uses the correct extension
 //------------------------------------------------------------------------
 
-func getCorrectExtension(fileWriter fyne.URIWriteCloser) fyne.URIWriteCloser {
-	uri := fileWriter.URI()
+func withExtension(uri fyne.URI) (fyne.URI, error) {
 	if uri.Extension() == ".jpdy" {
-		return fileWriter
+		return uri, nil
 	}
+	return storage.ParseURI(uri.String() + ".jpdy")
+}
 
-	// Need to re-get the correct URI, closing the current writer
-	fileWriter.Close()
-	storage.Delete(uri)
+//------------------------------------------------------------------------
+// Backups
+//------------------------------------------------------------------------
+// Whether saving a board should first copy the previous version to a
+// ".bak" file next to it
 
-	uri_string := uri.String() + ".jpdy"
-	uri, err := storage.ParseURI(uri_string)
-	if err != nil {
-		log.Fatal(err)
-	}
+var keepBackups = false
+
+func SetKeepBackups(keep bool) {
+	keepBackups = keep
+}
+
+func KeepBackups() bool {
+	return keepBackups
+}
 
-	f, err := storage.Writer(uri)
+//------------------------------------------------------------------------
+// writeURI
+//------------------------------------------------------------------------
+// Saves v to the given URI. Local files are written to a temporary file
+// first, so that a failed save leaves the original intact; other URIs
+// can only be written directly
+
+func writeURI(uri fyne.URI, v interface{}, keepBackup bool) error {
+	if uri.Scheme() == "file" {
+		return file.SaveFile(uri.Path(), v, keepBackup)
+	}
+	writer, err := storage.Writer(uri)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-	return f
+	return file.Save(writer, v)
 }
 
 //------------------------------------------------------------------------
 // Loading and Saving the Board
 //------------------------------------------------------------------------
 
-func LoadCurrBoard(fileReader fyne.URIReadCloser) {
-	file.Load(fileReader, &currBoard)
-	BoardChange()
+// The board is loaded separately first, so that the current board is left
+// untouched if the file can't be read
+
+func LoadCurrBoard(fileReader fyne.URIReadCloser) error {
+	var loaded *Board = nil
+	if err := file.Load(fileReader, &loaded); err != nil {
+		return fmt.Errorf("couldn't read %v: %w", fileReader.URI().Name(), err)
+	}
+	if loaded == nil {
+		return fmt.Errorf("%v doesn't contain a board", fileReader.URI().Name())
+	}
+	replaceBoard(loaded, fileReader.URI(), false)
+	return nil
 }
 
-func SaveCurrBoard(fileWriter fyne.URIWriteCloser) {
-	extensionWriter := getCorrectExtension(fileWriter)
-	file.Save(extensionWriter, currBoard)
+// Returns where the board was saved, which may have had the extension
+// added
+
+func SaveCurrBoard(uri fyne.URI) (fyne.URI, error) {
+	uri, err := withExtension(uri)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeURI(uri, currBoard, keepBackups); err != nil {
+		return nil, fmt.Errorf("couldn't save %v: %w", uri.Name(), err)
+	}
+	boardLock.Lock()
+	currURI = uri
+	dirty = false
+	boardLock.Unlock()
+	ClearBoardAutosave()
+	Redraw()
+	return uri, nil
 }
 
 //------------------------------------------------------------------------
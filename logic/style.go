@@ -9,6 +9,8 @@
 package logic
 
 import (
+	"encoding/json"
+	"fmt"
 	"image/color"
 	"io"
 	"path/filepath"
@@ -41,19 +43,104 @@ func NewStyle() *Style {
 	}
 }
 
+//------------------------------------------------------------------------
+// Hex Colors
+//------------------------------------------------------------------------
+// Colors are stored as "#rrggbbaa" strings, as encoding/json can't
+// unmarshal into the color.Color interface. A missing color is treated
+// as transparent
+
+func ColorToHex(c color.Color) string {
+	if c == nil {
+		c = color.Transparent
+	}
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x%02x", nrgba.R, nrgba.G, nrgba.B, nrgba.A)
+}
+
+func HexToColor(hex string) (color.Color, error) {
+	var r, g, b, a uint8
+	_, err := fmt.Sscanf(hex, "#%02x%02x%02x%02x", &r, &g, &b, &a)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %q", hex)
+	}
+	return color.NRGBA{r, g, b, a}, nil
+}
+
+//------------------------------------------------------------------------
+// JSON Marshalling
+//------------------------------------------------------------------------
+// Images are stored directly, as a fyne.StaticResource only holds a name
+// and (base64-encoded) content. Colors are kept raw, so that any that
+// can't be read fall back to the default (older versions stored the
+// color's fields directly, but those are converted when the file is
+// loaded)
+
+type styleJSON struct {
+	UseColor  bool
+	Color     json.RawMessage
+	Image     *fyne.StaticResource
+	TextColor json.RawMessage
+}
+
+func colorToJSON(c color.Color) json.RawMessage {
+	data, _ := json.Marshal(ColorToHex(c))
+	return data
+}
+
+func colorFromJSON(data json.RawMessage, fallback color.Color) color.Color {
+	var hex string
+	if json.Unmarshal(data, &hex) != nil {
+		return fallback
+	}
+	c, err := HexToColor(hex)
+	if err != nil {
+		return fallback
+	}
+	return c
+}
+
+func (s *Style) MarshalJSON() ([]byte, error) {
+	return json.Marshal(styleJSON{
+		UseColor:  s.UseColor,
+		Color:     colorToJSON(s.Color),
+		Image:     s.Image,
+		TextColor: colorToJSON(s.TextColor),
+	})
+}
+
+func (s *Style) UnmarshalJSON(data []byte) error {
+	var stored styleJSON
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	*s = *NewStyle()
+	s.UseColor = stored.UseColor
+	s.Image = stored.Image
+	s.Color = colorFromJSON(stored.Color, s.Color)
+	s.TextColor = colorFromJSON(stored.TextColor, s.TextColor)
+	return nil
+}
+
 //------------------------------------------------------------------------
 // Helper Functions
 //------------------------------------------------------------------------
 
-func ResourceFromURI(uri fyne.URI) *fyne.StaticResource {
-	reader, _ := storage.Reader(uri)
+func ResourceFromURI(uri fyne.URI) (*fyne.StaticResource, error) {
+	reader, err := storage.Reader(uri)
+	if err != nil {
+		return nil, err
+	}
 	defer reader.Close()
 
-	data, _ := io.ReadAll(reader)
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
 	return &fyne.StaticResource{
 		StaticName:    filepath.Base(uri.String()),
 		StaticContent: data,
-	}
+	}, nil
 }
 
 //------------------------------------------------------------------------
@@ -75,3 +162,25 @@ func NewGameStyle() *GameStyle {
 		QuestionStyle: NewStyle(),
 	}
 }
+
+//------------------------------------------------------------------------
+// GetStyle
+//------------------------------------------------------------------------
+// Returns the board's style, filling in any styles that are missing
+// (such as from files that were saved without one)
+
+func (b *Board) GetStyle() *GameStyle {
+	if b == nil {
+		return NewGameStyle()
+	}
+	if b.Style == nil {
+		b.Style = NewGameStyle()
+	}
+	if b.Style.CategoryStyle == nil {
+		b.Style.CategoryStyle = NewStyle()
+	}
+	if b.Style.QuestionStyle == nil {
+		b.Style.QuestionStyle = NewStyle()
+	}
+	return b.Style
+}
@@ -0,0 +1,188 @@
+//========================================================================
+// validate.go
+//========================================================================
+// Checking a board for problems before it's played
+//
+// Author: Aidan McNay
+// Date: June 23rd, 2024
+
+package logic
+
+import (
+	"fmt"
+	"strings"
+)
+
+//------------------------------------------------------------------------
+// Define a Problem Type
+//------------------------------------------------------------------------
+// Errors stop a board from being played properly, while warnings are
+// only likely to be mistakes. Round, Category and Question identify where
+// the problem is, and are nil if it isn't specific to one
+
+type Severity int
+
+const (
+	Warning Severity = iota
+	Error
+)
+
+func (s Severity) String() string {
+	if s == Error {
+		return "error"
+	}
+	return "warning"
+}
+
+type Problem struct {
+	Severity Severity
+	Round    *Round
+	Category *Category
+	Question *Question
+	Message  string
+}
+
+//------------------------------------------------------------------------
+// Describing a Problem
+//------------------------------------------------------------------------
+
+// Where the problem is, such as "Jeopardy > History > 200"
+
+func (p Problem) Location() string {
+	var parts []string = nil
+	if p.Round != nil {
+		parts = append(parts, p.Round.Name)
+	}
+	if p.Category != nil {
+		parts = append(parts, p.Category.Name)
+	}
+	if p.Question != nil {
+		parts = append(parts, fmt.Sprintf("%v", p.Question.Points))
+	}
+	return strings.Join(parts, " > ")
+}
+
+func (p Problem) String() string {
+	if location := p.Location(); location != "" {
+		return fmt.Sprintf("%v: %v: %v", p.Severity, location, p.Message)
+	}
+	return fmt.Sprintf("%v: %v", p.Severity, p.Message)
+}
+
+//------------------------------------------------------------------------
+// CountProblems
+//------------------------------------------------------------------------
+// Returns the number of errors and warnings
+
+func CountProblems(problems []Problem) (errors, warnings int) {
+	for _, p := range problems {
+		if p.Severity == Error {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	return errors, warnings
+}
+
+//------------------------------------------------------------------------
+// Validate
+//------------------------------------------------------------------------
+// Returns every problem with the board, in the order they appear on it
+
+func (b *Board) Validate() []Problem {
+	if b == nil {
+		return nil
+	}
+	var problems []Problem = nil
+	if len(b.Rounds) == 0 {
+		problems = append(problems,
+			Problem{Error, nil, nil, nil, "the board has no rounds"})
+	}
+
+	// Prompts that appear more than once are only reported the second
+	// time
+	prompts := map[string]*Category{}
+
+	for _, round := range b.Rounds {
+		problems = append(problems, round.validate(prompts)...)
+	}
+	problems = append(problems, b.validateFinal()...)
+	return problems
+}
+
+func (r *Round) validate(prompts map[string]*Category) []Problem {
+	var problems []Problem = nil
+	add := func(severity Severity, category *Category, question *Question,
+		format string, a ...interface{}) {
+		problems = append(problems, Problem{severity, r, category, question,
+			fmt.Sprintf(format, a...)})
+	}
+
+	if len(r.Categories) == 0 {
+		add(Error, nil, nil, "the round has no categories")
+	}
+	names := map[string]bool{}
+	for _, category := range r.Categories {
+		if strings.TrimSpace(category.Name) == "" {
+			add(Warning, category, nil, "the category has no name")
+		} else if names[category.Name] {
+			add(Warning, category, nil, "another category has the same name")
+		}
+		names[category.Name] = true
+
+		// Heights include the category's name, as on screen
+		if len(category.Questions) == 0 {
+			add(Error, category, nil, "the category has no questions")
+		} else if category.Height() < r.Height() {
+			add(Warning, category, nil,
+				"the category has %v questions, but others have %v",
+				category.Height()-1, r.Height()-1)
+		}
+
+		points := map[int]bool{}
+		for idx, q := range category.Questions {
+			if q.Points <= 0 {
+				add(Error, category, q, "points must be positive")
+			}
+			if points[q.Points] {
+				add(Warning, category, q,
+					"another question in the category has the same points")
+			}
+			points[q.Points] = true
+			if idx > 0 && q.Points < category.Questions[idx-1].Points {
+				add(Warning, category, q, "points aren't in ascending order")
+			}
+
+			if strings.TrimSpace(q.Prompt) == "" {
+				add(Error, category, q, "the prompt is empty")
+			} else if other, ok := prompts[q.Prompt]; ok {
+				add(Warning, category, q,
+					"the same prompt is also in %v", other.Name)
+			} else {
+				prompts[q.Prompt] = category
+			}
+			if strings.TrimSpace(q.Answer) == "" {
+				add(Error, category, q, "the answer is empty")
+			}
+		}
+	}
+	return problems
+}
+
+func (b *Board) validateFinal() []Problem {
+	q := b.FinalQuestion()
+	if q == nil {
+		return nil
+	}
+	var problems []Problem = nil
+	if strings.TrimSpace(q.Prompt) == "" {
+		problems = append(problems, Problem{Error, nil, b.Final, nil,
+			"the Final Jeopardy prompt is empty"})
+	}
+	if strings.TrimSpace(q.Answer) == "" {
+		problems = append(problems, Problem{Error, nil, b.Final, nil,
+			"the Final Jeopardy answer is empty"})
+	}
+	return problems
+}
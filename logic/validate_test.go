@@ -0,0 +1,147 @@
+//========================================================================
+// validate_test.go
+//========================================================================
+// Tests that each of the validator's rules reports the problems it should
+//
+// Author: Aidan McNay
+// Date: June 23rd, 2024
+
+package logic_test
+
+import (
+	"jeopardy/logic"
+	"strings"
+	"testing"
+)
+
+// A board with no problems, which each test then breaks in one way
+
+func validBoard() *logic.Board {
+	board := logic.MakeBoard("Valid")
+	history := logic.MakeCategory("History")
+	history.AddQuestions(
+		logic.MakeQuestion("The year the Berlin Wall fell", "What is 1989?", 200),
+		logic.MakeQuestion("He crossed the Rubicon", "Who is Caesar?", 400),
+	)
+	science := logic.MakeCategory("Science")
+	science.AddQuestions(
+		logic.MakeQuestion("H2O, more commonly", "What is water?", 200),
+		logic.MakeQuestion("The closest star to Earth", "What is the Sun?", 400),
+	)
+	board.Rounds[0].AddCategories(history, science)
+	board.SetFinal("Geography", "The longest river", "What is the Nile?")
+	return board
+}
+
+func TestValidBoard(t *testing.T) {
+	if problems := validBoard().Validate(); len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		breakIt  func(b *logic.Board)
+		severity logic.Severity
+		message  string
+	}{
+		{"no rounds", func(b *logic.Board) {
+			b.Rounds = nil
+		}, logic.Error, "the board has no rounds"},
+		{"no categories", func(b *logic.Board) {
+			b.Rounds[0].Categories = nil
+		}, logic.Error, "the round has no categories"},
+		{"empty category", func(b *logic.Board) {
+			b.Rounds[0].Categories[1].Questions = nil
+		}, logic.Error, "the category has no questions"},
+		{"short category", func(b *logic.Board) {
+			science := b.Rounds[0].Categories[1]
+			science.Questions = science.Questions[:1]
+		}, logic.Warning, "the category has 1 questions, but others have 2"},
+		{"unnamed category", func(b *logic.Board) {
+			b.Rounds[0].Categories[1].Name = " "
+		}, logic.Warning, "the category has no name"},
+		{"duplicate category", func(b *logic.Board) {
+			b.Rounds[0].Categories[1].Name = "History"
+		}, logic.Warning, "another category has the same name"},
+		{"non-positive points", func(b *logic.Board) {
+			b.Rounds[0].Categories[0].Questions[0].Points = 0
+		}, logic.Error, "points must be positive"},
+		{"duplicate points", func(b *logic.Board) {
+			b.Rounds[0].Categories[0].Questions[1].Points = 200
+		}, logic.Warning, "another question in the category has the same points"},
+		{"descending points", func(b *logic.Board) {
+			b.Rounds[0].Categories[0].Questions[1].Points = 100
+		}, logic.Warning, "points aren't in ascending order"},
+		{"duplicate prompt", func(b *logic.Board) {
+			b.Rounds[0].Categories[1].Questions[0].Prompt = "He crossed the Rubicon"
+		}, logic.Warning, "the same prompt is also in History"},
+		{"empty prompt", func(b *logic.Board) {
+			b.Rounds[0].Categories[0].Questions[0].Prompt = ""
+		}, logic.Error, "the prompt is empty"},
+		{"empty answer", func(b *logic.Board) {
+			b.Rounds[0].Categories[0].Questions[0].Answer = "\n"
+		}, logic.Error, "the answer is empty"},
+		{"empty final prompt", func(b *logic.Board) {
+			b.FinalQuestion().Prompt = ""
+		}, logic.Error, "the Final Jeopardy prompt is empty"},
+		{"empty final answer", func(b *logic.Board) {
+			b.FinalQuestion().Answer = ""
+		}, logic.Error, "the Final Jeopardy answer is empty"},
+	}
+
+	for _, test := range tests {
+		board := validBoard()
+		test.breakIt(board)
+		problems := board.Validate()
+		if len(problems) != 1 {
+			t.Errorf("%v: expected 1 problem, got %v", test.name, problems)
+			continue
+		}
+		if p := problems[0]; p.Severity != test.severity ||
+			!strings.Contains(p.Message, test.message) {
+			t.Errorf("%v: expected %v %q, got %v", test.name, test.severity,
+				test.message, p)
+		}
+	}
+}
+
+// Duplicate points are found anywhere in the category, not just next to
+// each other
+
+func TestValidateSeparatedDuplicatePoints(t *testing.T) {
+	board := validBoard()
+	history := board.Rounds[0].Categories[0]
+	// Appended directly, as adding questions keeps them sorted
+	history.Questions = append(history.Questions,
+		logic.MakeQuestion("The first man on the Moon", "Who is Armstrong?", 200))
+	board.Rounds[0].Categories[1].AddQuestions(
+		logic.MakeQuestion("The largest planet", "What is Jupiter?", 600),
+	)
+
+	problems := board.Validate()
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got %v", problems)
+	}
+	for i, message := range []string{
+		"another question in the category has the same points",
+		"points aren't in ascending order",
+	} {
+		if p := problems[i]; p.Question != history.Questions[2] ||
+			!strings.Contains(p.Message, message) {
+			t.Errorf("expected %q on the last question, got %v", message, p)
+		}
+	}
+}
+
+func TestCountProblems(t *testing.T) {
+	board := validBoard()
+	board.Rounds[0].Categories[0].Questions[0].Answer = ""
+	board.Rounds[0].Categories[1].Name = "History"
+	errors, warnings := logic.CountProblems(board.Validate())
+	if errors != 1 || warnings != 1 {
+		t.Errorf("expected 1 error and 1 warning, got %v and %v",
+			errors, warnings)
+	}
+}
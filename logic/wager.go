@@ -0,0 +1,38 @@
+//========================================================================
+// wager.go
+//========================================================================
+// Functions for validating wagers on Daily Doubles
+//
+// Author: Aidan McNay
+// Date: June 14th, 2024
+
+package logic
+
+import "fmt"
+
+//------------------------------------------------------------------------
+// Wager Limits
+//------------------------------------------------------------------------
+// Per the usual rules, a player may always wager at least MinWager, and
+// at most the greater of their score and the round's highest value
+
+const MinWager = 5
+
+func MaxWager(score, maxPoints int) int {
+	return max(score, maxPoints, MinWager)
+}
+
+//------------------------------------------------------------------------
+// ValidateWager
+//------------------------------------------------------------------------
+// Checks whether a wager is allowed for a player with the given score
+
+func ValidateWager(wager, score, maxPoints int) error {
+	if wager < MinWager {
+		return fmt.Errorf("wager must be at least %v", MinWager)
+	}
+	if limit := MaxWager(score, maxPoints); wager > limit {
+		return fmt.Errorf("wager can be at most %v", limit)
+	}
+	return nil
+}
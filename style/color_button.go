@@ -55,6 +55,7 @@ type ColorButton struct {
 	widget.BaseWidget
 	Text      string
 	FillColor color.Color
+	TextColor color.Color
 	TextSize  float32
 	OnTap     func()
 	hovered   bool
@@ -88,16 +89,9 @@ var darkStrokeColor color.Color = color.NRGBA{0x17, 0x17, 0x18, 0xff}
 func (b *ColorButton) CreateRenderer() fyne.WidgetRenderer {
 	b.ExtendBaseWidget(b)
 
-	var textColor color.Color
-	switch {
-	case isLight(b.FillColor):
-		textColor = darkStrokeColor
-	default:
-		textColor = lightStrokeColor
-	}
 	text := canvas.NewText(
 		b.Text,
-		textColor,
+		color.Transparent,
 	)
 	text.Alignment = fyne.TextAlignCenter
 	text.TextStyle = fyne.TextStyle{Bold: true}
@@ -107,12 +101,14 @@ func (b *ColorButton) CreateRenderer() fyne.WidgetRenderer {
 		FillColor: b.FillColor,
 	}
 	rectangle.CornerRadius = theme.InputRadiusSize()
-	return &colorButtonRenderer{
+	r := &colorButtonRenderer{
 		objects:   []fyne.CanvasObject{rectangle, text},
 		button:    b,
 		text:      text,
 		rectangle: rectangle,
 	}
+	text.Color = r.strokeColor()
+	return r
 }
 
 //------------------------------------------------------------------------
@@ -224,11 +220,14 @@ func (r *colorButtonRenderer) MinSize() fyne.Size {
 }
 
 //------------------------------------------------------------------------
-// textColor determines the text color of our button, intended to
-// have maximum contrast with the fill color
+// strokeColor determines the text color of our button, intended to
+// have maximum contrast with the fill color unless a text color was given
 //------------------------------------------------------------------------
 
 func (r *colorButtonRenderer) strokeColor() color.Color {
+	if r.button.TextColor != nil {
+		return r.button.TextColor
+	}
 	fillColor := r.button.FillColor
 	switch {
 	case isLight(fillColor):
@@ -289,11 +289,11 @@ func getBackgroundColor() *color.Color {
 }
 
 //------------------------------------------------------------------------
-// openColorPrompt
+// OpenColorPrompt
 //------------------------------------------------------------------------
 // Opens a color prompt to update the given color
 
-func openColorPrompt(colorPtr *color.Color, callback func(), win fyne.Window) {
+func OpenColorPrompt(colorPtr *color.Color, callback func(), win fyne.Window) {
 	prompt := dialog.NewColorPicker("Pick a New Color", "", func(c color.Color) {
 		*colorPtr = c
 		callback()
@@ -358,35 +358,35 @@ func ColorDialog(win fyne.Window) {
 	}
 
 	backgroundColorButton.OnTapped(func() {
-		openColorPrompt(
+		OpenColorPrompt(
 			&tempBackgroundColor,
 			updateBackground,
 			win,
 		)
 	})
 	primaryColorButton.OnTapped(func() {
-		openColorPrompt(
+		OpenColorPrompt(
 			&tempPrimaryColor,
 			updatePrimary,
 			win,
 		)
 	})
 	titleColorButton.OnTapped(func() {
-		openColorPrompt(
+		OpenColorPrompt(
 			&tempTitleColor,
 			updateTitle,
 			win,
 		)
 	})
 	questionColorButton.OnTapped(func() {
-		openColorPrompt(
+		OpenColorPrompt(
 			&tempQuestionColor,
 			updateQuestion,
 			win,
 		)
 	})
 	categoryColorButton.OnTapped(func() {
-		openColorPrompt(
+		OpenColorPrompt(
 			&tempCategoryColor,
 			updateCategory,
 			win,
@@ -435,7 +435,7 @@ func ColorDialog(win fyne.Window) {
 		questionColor = tempQuestionColor
 		categoryColor = tempCategoryColor
 		StoreColorPreferences(fyne.CurrentApp())
-		logic.BoardChange()
+		logic.Redraw()
 	}
 
 	resetButton := widget.NewButton(
@@ -0,0 +1,480 @@
+//========================================================================
+// csv.go
+//========================================================================
+// Importing questions from spreadsheets (and exporting them back), saved
+// as CSV
+//
+// Author: Aidan McNay
+// Date: June 24th, 2024
+
+package transfer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"jeopardy/logic"
+	"strconv"
+	"strings"
+)
+
+//------------------------------------------------------------------------
+// Define the Columns
+//------------------------------------------------------------------------
+// Each question is a row with these columns. Flags are separated by
+// spaces, and can be "dd" for a Daily Double or "time=N" for a time limit
+// of N seconds. The multiplier is the round's, so it only needs to be
+// given on one of the round's rows
+//
+// Final Jeopardy is a row in the round named FinalRound, which needs no
+// points. Rows with no points, prompt or answer add an empty round or
+// category
+
+type Column int
+
+const (
+	ColumnRound Column = iota
+	ColumnCategory
+	ColumnPoints
+	ColumnPrompt
+	ColumnAnswer
+	ColumnFlags
+	ColumnMultiplier
+	NumColumns
+)
+
+var ColumnNames = [NumColumns]string{
+	"Round", "Category", "Points", "Prompt", "Answer", "Flags", "Multiplier",
+}
+
+const FinalRound = "Final Jeopardy"
+
+// Which column of the file each of our columns comes from, or -1 if it
+// isn't in the file
+
+type Mapping [NumColumns]int
+
+const NotMapped = -1
+
+//------------------------------------------------------------------------
+// Define a Table
+//------------------------------------------------------------------------
+// The rows read from a CSV file, along with the line each started on
+
+type Table struct {
+	Rows  [][]string
+	Lines []int
+}
+
+// Spreadsheets often start UTF-8 files with a byte order mark, which
+// isn't part of the first cell
+
+func ReadCSV(r io.Reader) (*Table, error) {
+	buffered := bufio.NewReader(r)
+	if first, _, err := buffered.ReadRune(); err == nil && first != '\uFEFF' {
+		buffered.UnreadRune()
+	}
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	table := &Table{}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		table.Rows = append(table.Rows, row)
+		table.Lines = append(table.Lines, line)
+	}
+	if len(table.Rows) == 0 {
+		return nil, errors.New("the file is empty")
+	}
+	return table, nil
+}
+
+// The most columns in any row
+
+func (t *Table) Width() int {
+	width := 0
+	for _, row := range t.Rows {
+		width = max(width, len(row))
+	}
+	return width
+}
+
+//------------------------------------------------------------------------
+// GuessMapping
+//------------------------------------------------------------------------
+// Matches columns by name if the first row is a header. Otherwise, the
+// columns are assumed to be in order, with the round left out if there
+// aren't enough for it (as the multiplier usually is)
+
+func (t *Table) GuessMapping() (Mapping, bool) {
+	var mapping Mapping
+	for i := range mapping {
+		mapping[i] = NotMapped
+	}
+
+	hasHeader := false
+	for idx, cell := range t.Rows[0] {
+		for column, name := range ColumnNames {
+			if strings.EqualFold(strings.TrimSpace(cell), name) {
+				mapping[column] = idx
+				hasHeader = true
+			}
+		}
+	}
+	if hasHeader {
+		return mapping, true
+	}
+
+	first := ColumnRound
+	if t.Width() < int(ColumnMultiplier) {
+		first = ColumnCategory
+	}
+	for column := first; column < NumColumns; column++ {
+		if idx := int(column - first); idx < t.Width() {
+			mapping[column] = idx
+		}
+	}
+	return mapping, false
+}
+
+//------------------------------------------------------------------------
+// Define a Row Error
+//------------------------------------------------------------------------
+// A row that couldn't be imported, and why
+
+type RowError struct {
+	Line    int
+	Message string
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %v: %v", e.Line, e.Message)
+}
+
+//------------------------------------------------------------------------
+// Parsing Rows
+//------------------------------------------------------------------------
+
+// The question is nil for rows that only add a round or category, and
+// the multiplier is 0 if it isn't given
+
+type csvRow struct {
+	round      string
+	category   string
+	multiplier int
+	question   *logic.Question
+}
+
+func cell(row []string, mapping Mapping, column Column) string {
+	idx := mapping[column]
+	if idx == NotMapped || idx >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[idx])
+}
+
+func parseFlags(flags string, question *logic.Question) error {
+	for _, flag := range strings.Fields(flags) {
+		flag = strings.ToLower(flag)
+		switch {
+		case flag == "dd":
+			question.DailyDouble = true
+		case strings.HasPrefix(flag, "time="):
+			seconds, err := strconv.Atoi(strings.TrimPrefix(flag, "time="))
+			if err != nil || seconds < 0 {
+				return fmt.Errorf("%q isn't a valid time limit", flag)
+			}
+			question.TimeLimit = seconds
+		default:
+			return fmt.Errorf("unknown flag %q", flag)
+		}
+	}
+	return nil
+}
+
+func parseRow(row []string, mapping Mapping) (*csvRow, error) {
+	parsed := &csvRow{
+		round:    cell(row, mapping, ColumnRound),
+		category: cell(row, mapping, ColumnCategory),
+	}
+	if text := cell(row, mapping, ColumnMultiplier); text != "" {
+		multiplier, err := strconv.Atoi(text)
+		if err != nil || multiplier <= 0 {
+			return nil, fmt.Errorf("%q isn't a valid multiplier", text)
+		}
+		parsed.multiplier = multiplier
+	}
+
+	pointsText := strings.TrimPrefix(cell(row, mapping, ColumnPoints), "$")
+	prompt := cell(row, mapping, ColumnPrompt)
+	answer := cell(row, mapping, ColumnAnswer)
+	isFinal := parsed.round == FinalRound
+	if pointsText == "" && prompt == "" && answer == "" && !isFinal {
+		if parsed.round == "" && parsed.category == "" {
+			return nil, errors.New("the row has no round, category or question")
+		}
+		return parsed, nil
+	}
+
+	if parsed.category == "" {
+		return nil, errors.New("the category is empty")
+	}
+	points := 0
+	if !isFinal || pointsText != "" {
+		var err error
+		if points, err = strconv.Atoi(pointsText); err != nil {
+			return nil, fmt.Errorf("%q isn't a valid number of points",
+				pointsText)
+		}
+	}
+	if prompt == "" {
+		return nil, errors.New("the prompt is empty")
+	}
+	if answer == "" {
+		return nil, errors.New("the answer is empty")
+	}
+
+	parsed.question = logic.MakeQuestion(prompt, answer, points)
+	if err := parseFlags(cell(row, mapping, ColumnFlags), parsed.question); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+func isBlank(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+//------------------------------------------------------------------------
+// Importing a Table
+//------------------------------------------------------------------------
+// Existing rounds and categories are only changed through the returned
+// command, so that the import can be undone. New ones can be filled in
+// directly, as they aren't on the board until the command is applied
+
+type importer struct {
+	board       *logic.Board
+	rounds      [](*logic.Round)
+	categories  map[*logic.Round][](*logic.Category)
+	questions   map[*logic.Category][](*logic.Question)
+	multipliers map[*logic.Round]int
+	final       *logic.Category
+}
+
+func (im *importer) round(name string) *logic.Round {
+	if name == "" {
+		if len(im.rounds) > 0 {
+			return im.rounds[0]
+		}
+		name = "Jeopardy"
+	}
+	for _, v := range im.rounds {
+		if v.Name == name {
+			return v
+		}
+	}
+	round := logic.MakeRound(name)
+	im.rounds = append(im.rounds, round)
+	return round
+}
+
+func (im *importer) category(round *logic.Round, name string) *logic.Category {
+	categories, ok := im.categories[round]
+	if !ok {
+		categories = append([](*logic.Category){}, round.Categories...)
+	}
+	for _, v := range categories {
+		if v.Name == name {
+			return v
+		}
+	}
+	category := logic.MakeCategory(name)
+	im.categories[round] = append(categories, category)
+	return category
+}
+
+func (im *importer) add(row *csvRow) error {
+	if row.round == FinalRound {
+		if im.final != nil {
+			return errors.New("Final Jeopardy was already given on an earlier row")
+		}
+		im.final = logic.MakeCategory(row.category)
+		im.final.AddQuestions(row.question)
+		return nil
+	}
+
+	round := im.round(row.round)
+	if row.multiplier > 0 {
+		im.multipliers[round] = row.multiplier
+	}
+	if row.category == "" {
+		return nil
+	}
+	category := im.category(round, row.category)
+	if row.question == nil {
+		return nil
+	}
+	questions, ok := im.questions[category]
+	if !ok {
+		questions = append([](*logic.Question){}, category.Questions...)
+	}
+	im.questions[category] = append(questions, row.question)
+	return nil
+}
+
+func (im *importer) command() logic.Command {
+	cmds := []logic.Command{
+		logic.SetCommand("", &im.board.Rounds, im.rounds),
+	}
+	for round, categories := range im.categories {
+		cmds = append(cmds, logic.SetCommand("", &round.Categories, categories))
+	}
+	for category, questions := range im.questions {
+		// Questions are kept sorted by points
+		sorted := logic.MakeCategory(category.Name)
+		sorted.AddQuestions(questions...)
+		cmds = append(cmds,
+			logic.SetCommand("", &category.Questions, sorted.Questions))
+	}
+	for round, multiplier := range im.multipliers {
+		cmds = append(cmds, logic.SetCommand("", &round.Multiplier, multiplier))
+	}
+	if im.final != nil {
+		cmds = append(cmds, logic.SetCommand("", &im.board.Final, im.final))
+	}
+	return logic.BatchCommand("Import Questions", cmds...)
+}
+
+// ImportCommand returns a command that adds every row of the table to
+// the board, along with how many questions will be imported and the rows
+// that couldn't be. Blank rows (and the header, if there is one) are
+// skipped, and Final Jeopardy replaces any the board already has
+
+func ImportCommand(board *logic.Board,
+	table *Table,
+	mapping Mapping,
+	hasHeader bool,
+) (logic.Command, int, []RowError) {
+	im := &importer{
+		board:       board,
+		rounds:      append([](*logic.Round){}, board.Rounds...),
+		categories:  map[*logic.Round][](*logic.Category){},
+		questions:   map[*logic.Category][](*logic.Question){},
+		multipliers: map[*logic.Round]int{},
+	}
+
+	imported := 0
+	var rowErrors []RowError = nil
+	for idx, row := range table.Rows {
+		if (idx == 0 && hasHeader) || isBlank(row) {
+			continue
+		}
+		parsed, err := parseRow(row, mapping)
+		if err == nil {
+			err = im.add(parsed)
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{table.Lines[idx], err.Error()})
+			continue
+		}
+		if parsed.question != nil {
+			imported++
+		}
+	}
+	return im.command(), imported, rowErrors
+}
+
+//------------------------------------------------------------------------
+// ImportBoard
+//------------------------------------------------------------------------
+// Creates a new board from the table alone
+
+func ImportBoard(name string,
+	table *Table,
+	mapping Mapping,
+	hasHeader bool,
+) (*logic.Board, int, []RowError) {
+	board := logic.MakeBoard(name)
+	board.Rounds = nil
+	cmd, imported, rowErrors := ImportCommand(board, table, mapping, hasHeader)
+	cmd.Do()
+	if len(board.Rounds) == 0 {
+		board.Rounds = [](*logic.Round){logic.MakeRound("Jeopardy")}
+	}
+	return board, imported, rowErrors
+}
+
+//------------------------------------------------------------------------
+// Exporting a Board
+//------------------------------------------------------------------------
+// Boards are exported with a header and every column, so that they can be
+// imported again unchanged. Points are written before the round's
+// multiplier is applied, which is given on every row. Attached media
+// can't be included
+
+var CSVExporter = &Exporter{
+	Name:        "csv",
+	Extension:   ".csv",
+	Description: "CSV Spreadsheet",
+	Write:       writeCSV,
+}
+
+func formatFlags(question *logic.Question) string {
+	var flags []string = nil
+	if question.DailyDouble {
+		flags = append(flags, "dd")
+	}
+	if question.TimeLimit > 0 {
+		flags = append(flags, fmt.Sprintf("time=%v", question.TimeLimit))
+	}
+	return strings.Join(flags, " ")
+}
+
+func writeCSV(out io.Writer, board *logic.Board, _ Options) error {
+	w := csv.NewWriter(out)
+	w.Write(ColumnNames[:])
+	for _, round := range board.Rounds {
+		multiplier := strconv.Itoa(round.GetMultiplier())
+		if len(round.Categories) == 0 {
+			w.Write([]string{round.Name, "", "", "", "", "", multiplier})
+		}
+		for _, category := range round.Categories {
+			if len(category.Questions) == 0 {
+				w.Write([]string{round.Name, category.Name, "", "", "", "",
+					multiplier})
+			}
+			for _, q := range category.Questions {
+				w.Write([]string{
+					round.Name,
+					category.Name,
+					strconv.Itoa(q.Points),
+					q.Prompt,
+					q.Answer,
+					formatFlags(q),
+					multiplier,
+				})
+			}
+		}
+	}
+	if q := board.FinalQuestion(); q != nil {
+		w.Write([]string{FinalRound, board.Final.Name, "", q.Prompt, q.Answer,
+			"", ""})
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,136 @@
+//========================================================================
+// csv_test.go
+//========================================================================
+// Tests that boards survive being exported to CSV and imported again
+//
+// Author: Aidan McNay
+// Date: June 25th, 2024
+
+package transfer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"jeopardy/logic"
+	"jeopardy/transfer"
+	"strings"
+	"testing"
+)
+
+func testBoard() *logic.Board {
+	board := logic.MakeBoard("Round Trip")
+
+	history := logic.MakeCategory("History, Ancient & Modern")
+	history.AddQuestions(
+		logic.MakeQuestion("The year the Berlin Wall fell", "What is 1989?", 200),
+		logic.MakeQuestion("He said \"Veni, vidi, vici\"", "Who is Caesar?", 400),
+	)
+	history.Questions[1].DailyDouble = true
+
+	science := logic.MakeCategory("Science")
+	science.AddQuestions(
+		logic.MakeQuestion("H2O, more commonly", "What is water?", 200),
+	)
+	science.Questions[0].TimeLimit = 15
+	board.Rounds[0].AddCategories(history, science)
+
+	// Rounds made in the editor after the first are worth more
+	double := logic.MakeRound("Double Jeopardy")
+	double.Multiplier = 2
+	art := logic.MakeCategory("Art")
+	art.AddQuestions(logic.MakeQuestion("He painted\nThe Starry Night",
+		"Who is van Gogh?", 400))
+	double.AddCategories(art, logic.MakeCategory("Still to Write"))
+	board.AddRounds(double, logic.MakeRound("Tiebreakers"))
+
+	board.SetFinal("Geography", "The longest river", "What is the Nile?")
+	return board
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	board := testBoard()
+
+	var out bytes.Buffer
+	if err := transfer.CSVExporter.Write(&out, board, nil); err != nil {
+		t.Fatal(err)
+	}
+	table, err := transfer.ReadCSV(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapping, hasHeader := table.GuessMapping()
+	if !hasHeader {
+		t.Error("expected the export's header to be recognized")
+	}
+	imported, count, rowErrors := transfer.ImportBoard(board.Name, table,
+		mapping, hasHeader)
+	if len(rowErrors) > 0 {
+		t.Fatalf("unexpected row errors: %v", rowErrors)
+	}
+	// Final Jeopardy is imported as well
+	if want := len(board.Questions()) + 1; count != want {
+		t.Errorf("imported %v questions, want %v", count, want)
+	}
+
+	before, _ := json.Marshal(board.Rounds)
+	after, _ := json.Marshal(imported.Rounds)
+	if string(before) != string(after) {
+		t.Errorf("rounds changed:\nbefore: %s\nafter:  %s", before, after)
+	}
+	before, _ = json.Marshal(board.Final)
+	after, _ = json.Marshal(imported.Final)
+	if string(before) != string(after) {
+		t.Errorf("Final Jeopardy changed:\nbefore: %s\nafter:  %s", before,
+			after)
+	}
+}
+
+func TestCSVImportErrors(t *testing.T) {
+	input := "History,200,A prompt,An answer\n" +
+		"History,lots,A prompt,An answer\n" +
+		"\n" +
+		",200,A prompt,An answer\n" +
+		"Art,100,A prompt,An answer,sparkly\n"
+	table, err := transfer.ReadCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapping, hasHeader := table.GuessMapping()
+	if hasHeader {
+		t.Error("expected no header")
+	}
+	_, count, rowErrors := transfer.ImportBoard("Errors", table, mapping,
+		hasHeader)
+	if count != 1 {
+		t.Errorf("imported %v questions, want 1", count)
+	}
+
+	var lines []int = nil
+	for _, v := range rowErrors {
+		lines = append(lines, v.Line)
+	}
+	if len(lines) != 3 || lines[0] != 2 || lines[1] != 4 || lines[2] != 5 {
+		t.Errorf("errors on lines %v, want [2 4 5]", lines)
+	}
+}
+
+func TestCSVByteOrderMark(t *testing.T) {
+	input := "\uFEFFRound,Category,Points,Prompt,Answer,Flags\n" +
+		"Double Jeopardy,History,200,A prompt,An answer,dd\n"
+	table, err := transfer.ReadCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapping, hasHeader := table.GuessMapping()
+	if !hasHeader || mapping[transfer.ColumnRound] != 0 {
+		t.Fatalf("expected the round to be the first column, got %v", mapping)
+	}
+	board, count, rowErrors := transfer.ImportBoard("BOM", table, mapping,
+		hasHeader)
+	if count != 1 || len(rowErrors) != 0 {
+		t.Fatalf("imported %v questions with errors %v", count, rowErrors)
+	}
+	if name := board.Rounds[0].Name; name != "Double Jeopardy" {
+		t.Errorf("imported into %q, want Double Jeopardy", name)
+	}
+}
@@ -0,0 +1,475 @@
+//========================================================================
+// playable.go
+//========================================================================
+// Exporting a board as a single HTML page that can be played in a
+// browser, without the app or an internet connection
+//
+// Author: Aidan McNay
+// Date: June 27th, 2024
+
+package transfer
+
+import (
+	"encoding/base64"
+	"html/template"
+	"io"
+	"jeopardy/logic"
+	"mime"
+	"net/http"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+)
+
+var PlayableExporter = &Exporter{
+	Name:        "html",
+	Extension:   ".html",
+	Description: "Playable Web Page",
+	Write:       writePlayable,
+}
+
+//------------------------------------------------------------------------
+// Define the Exported Data
+//------------------------------------------------------------------------
+// Everything the page needs is embedded in it as JSON. Images become data
+// URIs, so that there's nothing else to load
+
+type playableStyle struct {
+	Background string
+	Text       string
+	Image      string
+}
+
+type playableQuestion struct {
+	Value       int
+	Prompt      string
+	Answer      string
+	DailyDouble bool
+	PromptImage string `json:",omitempty"`
+	PromptAudio string `json:",omitempty"`
+	AnswerImage string `json:",omitempty"`
+	AnswerAudio string `json:",omitempty"`
+}
+
+type playableCategory struct {
+	Name      string
+	Questions []playableQuestion
+}
+
+type playableRound struct {
+	Name       string
+	MaxValue   int
+	Categories []playableCategory
+}
+
+type playableFinal struct {
+	Category string
+	Prompt   string
+	Answer   string
+}
+
+type playableBoard struct {
+	Name          string
+	Rounds        []playableRound
+	Final         *playableFinal
+	Players       []string
+	MinWager      int
+	CategoryStyle playableStyle
+	QuestionStyle playableStyle
+}
+
+//------------------------------------------------------------------------
+// Converting Styles
+//------------------------------------------------------------------------
+// Styles that defer to the app's theme use its default colors instead
+
+const (
+	defaultCategoryColor = "#8bc34aff"
+	defaultQuestionColor = "#296ff6ff"
+	defaultTextColor     = "#ffffffff"
+)
+
+func dataURI(resource *fyne.StaticResource) string {
+	if resource == nil {
+		return ""
+	}
+	mimeType := mime.TypeByExtension(filepath.Ext(resource.StaticName))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(resource.StaticContent)
+	}
+	return "data:" + mimeType + ";base64," +
+		base64.StdEncoding.EncodeToString(resource.StaticContent)
+}
+
+func convertStyle(s *logic.Style, fallback string) playableStyle {
+	if !s.UseColor && s.Image != nil {
+		return playableStyle{"transparent", logic.ColorToHex(s.TextColor),
+			dataURI(s.Image)}
+	}
+	if _, _, _, a := s.Color.RGBA(); a == 0 {
+		return playableStyle{fallback, defaultTextColor, ""}
+	}
+	return playableStyle{logic.ColorToHex(s.Color),
+		logic.ColorToHex(s.TextColor), ""}
+}
+
+//------------------------------------------------------------------------
+// writePlayable
+//------------------------------------------------------------------------
+// Every game starts from the beginning, with all questions unanswered and
+// the board's players starting from zero
+
+func writePlayable(w io.Writer, board *logic.Board, _ Options) error {
+	gameStyle := board.GetStyle()
+	data := playableBoard{
+		Name:          board.Name,
+		MinWager:      logic.MinWager,
+		CategoryStyle: convertStyle(gameStyle.CategoryStyle, defaultCategoryColor),
+		QuestionStyle: convertStyle(gameStyle.QuestionStyle, defaultQuestionColor),
+		Players:       []string{},
+		Rounds:        []playableRound{},
+	}
+	for _, round := range board.Rounds {
+		r := playableRound{round.Name, round.MaxValue(), []playableCategory{}}
+		for _, category := range round.Categories {
+			c := playableCategory{category.Name, []playableQuestion{}}
+			for _, q := range category.Questions {
+				c.Questions = append(c.Questions, playableQuestion{
+					round.Value(q), q.Prompt, q.Answer, q.DailyDouble,
+					dataURI(q.PromptImage), dataURI(q.PromptAudio),
+					dataURI(q.AnswerImage), dataURI(q.AnswerAudio),
+				})
+			}
+			r.Categories = append(r.Categories, c)
+		}
+		data.Rounds = append(data.Rounds, r)
+	}
+	if q := board.FinalQuestion(); q != nil {
+		data.Final = &playableFinal{board.Final.Name, q.Prompt, q.Answer}
+	}
+	for _, p := range board.Players {
+		data.Players = append(data.Players, p.GetName())
+	}
+	return playableTemplate.Execute(w, data)
+}
+
+//------------------------------------------------------------------------
+// Define the Template
+//------------------------------------------------------------------------
+// Text is always inserted with textContent, so that nothing on the board
+// is interpreted as HTML
+
+var playableTemplate = template.Must(template.New("playable").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Name}}</title>
+<style>
+	* { box-sizing: border-box; }
+	body { margin: 0; font-family: sans-serif; background: #171718; color: #fff;
+		display: flex; flex-direction: column; height: 100vh; }
+	header { display: flex; align-items: center; gap: 1em; padding: 0.5em 1em; }
+	header h1 { font-size: 1.4em; margin: 0; flex: 1; }
+	button { font-size: 1em; padding: 0.4em 0.8em; cursor: pointer; }
+	main { flex: 1; position: relative; padding: 0.5em; min-height: 0; }
+	#board { display: grid; gap: 6px; height: 100%; }
+	.tile { display: flex; align-items: center; justify-content: center;
+		text-align: center; border: none; border-radius: 4px; padding: 0.3em;
+		background-size: cover; background-position: center; font-weight: bold; }
+	.category { font-size: 1.3em; cursor: default; }
+	.question { font-size: 2em; }
+	.answered { background: #808080 !important; cursor: default; }
+	#screen { position: absolute; inset: 0; display: none; flex-direction: column;
+		align-items: center; justify-content: center; gap: 1em; padding: 2em;
+		text-align: center; background: #171718; }
+	#screen.open { display: flex; }
+	#heading { font-size: 1.3em; opacity: 0.8; }
+	#text { font-size: 2.5em; font-weight: bold; white-space: pre-wrap; }
+	#answer { font-size: 2em; font-style: italic; white-space: pre-wrap; }
+	#media { display: flex; flex-direction: column; align-items: center; gap: 0.5em; }
+	#media img { max-width: 60vw; max-height: 40vh; }
+	#controls { display: flex; flex-wrap: wrap; gap: 0.5em; justify-content: center; }
+	.row { display: flex; gap: 0.5em; align-items: center; }
+	footer { display: flex; flex-wrap: wrap; gap: 1em; padding: 0.5em 1em;
+		border-top: 1px solid #444; align-items: center; }
+	.player { text-align: center; cursor: pointer; }
+	.player .score { font-size: 1.4em; font-weight: bold; }
+	.negative { color: #f66; }
+</style>
+</head>
+<body>
+<header>
+	<h1 id="title"></h1>
+	<button id="next" hidden></button>
+</header>
+<main>
+	<div id="board"></div>
+	<div id="screen">
+		<div id="heading"></div>
+		<div id="text"></div>
+		<div id="answer"></div>
+		<div id="media"></div>
+		<div id="controls"></div>
+	</div>
+</main>
+<footer id="scores"></footer>
+<script>
+"use strict";
+const board = {{.}};
+
+let roundIdx = 0;
+const answered = new Set();
+const players = board.Players.map(function (name) { return { name: name, score: 0 }; });
+
+function el(tag, text, className) {
+	const e = document.createElement(tag);
+	if (text !== undefined) { e.textContent = text; }
+	if (className) { e.className = className; }
+	return e;
+}
+
+function button(text, onClick) {
+	const b = el("button", text);
+	b.addEventListener("click", onClick);
+	return b;
+}
+
+function applyStyle(e, style) {
+	e.style.background = style.Background;
+	e.style.color = style.Text;
+	if (style.Image) { e.style.backgroundImage = "url(" + style.Image + ")"; }
+}
+
+// Scores
+
+function renderScores() {
+	const footer = document.getElementById("scores");
+	footer.replaceChildren();
+	players.forEach(function (p) {
+		const e = el("div", undefined, "player");
+		e.appendChild(el("div", p.name));
+		e.appendChild(el("div", "$" + p.score, "score" + (p.score < 0 ? " negative" : "")));
+		e.title = "Click to change the score";
+		e.addEventListener("click", function () {
+			const score = prompt("New score for " + p.name, p.score);
+			if (score !== null && !isNaN(parseInt(score, 10))) {
+				p.score = parseInt(score, 10);
+				renderScores();
+			}
+		});
+		footer.appendChild(e);
+	});
+	footer.appendChild(button("Add Player", function () {
+		const name = prompt("Player name");
+		if (name) {
+			players.push({ name: name, score: 0 });
+			renderScores();
+		}
+	}));
+}
+
+// The board
+
+function questionKey(r, c, q) { return r + "/" + c + "/" + q; }
+
+function roundDone() {
+	const round = board.Rounds[roundIdx];
+	return round.Categories.every(function (cat, c) {
+		return cat.Questions.every(function (_, q) {
+			return answered.has(questionKey(roundIdx, c, q));
+		});
+	});
+}
+
+function renderBoard() {
+	const round = board.Rounds[roundIdx];
+	document.getElementById("title").textContent = board.Name + " - " + round.Name;
+	const grid = document.getElementById("board");
+	grid.replaceChildren();
+	const width = Math.max(round.Categories.length, 1);
+	const height = Math.max.apply(null, [0].concat(round.Categories.map(function (c) {
+		return c.Questions.length;
+	})));
+	grid.style.gridTemplateColumns = "repeat(" + width + ", 1fr)";
+	grid.style.gridTemplateRows = "repeat(" + (height + 1) + ", 1fr)";
+
+	round.Categories.forEach(function (cat) {
+		const tile = el("div", cat.Name, "tile category");
+		applyStyle(tile, board.CategoryStyle);
+		grid.appendChild(tile);
+	});
+	for (let q = 0; q < height; q++) {
+		round.Categories.forEach(function (cat, c) {
+			const question = cat.Questions[q];
+			if (!question) {
+				grid.appendChild(el("div"));
+				return;
+			}
+			const key = questionKey(roundIdx, c, q);
+			const tile = el("button", "", "tile question");
+			applyStyle(tile, board.QuestionStyle);
+			if (answered.has(key)) {
+				tile.classList.add("answered");
+			} else {
+				tile.textContent = "$" + question.Value;
+				tile.addEventListener("click", function () {
+					openQuestion(cat, question, key);
+				});
+			}
+			grid.appendChild(tile);
+		});
+	}
+	renderNext();
+}
+
+function renderNext() {
+	const next = document.getElementById("next");
+	next.hidden = true;
+	if (!roundDone()) { return; }
+	if (roundIdx + 1 < board.Rounds.length) {
+		next.textContent = "Next Round: " + board.Rounds[roundIdx + 1].Name;
+		next.onclick = function () { roundIdx++; renderBoard(); };
+		next.hidden = false;
+	} else if (board.Final) {
+		next.textContent = "Final Jeopardy";
+		next.onclick = openFinal;
+		next.hidden = false;
+	}
+}
+
+// The question screen
+
+function showScreen(heading, text) {
+	document.getElementById("heading").textContent = heading;
+	document.getElementById("text").textContent = text;
+	document.getElementById("answer").textContent = "";
+	document.getElementById("media").replaceChildren();
+	document.getElementById("controls").replaceChildren();
+	document.getElementById("screen").classList.add("open");
+}
+
+function setControls() {
+	const controls = document.getElementById("controls");
+	controls.replaceChildren.apply(controls, arguments);
+}
+
+// Shows any image or audio attached to the prompt or answer
+
+function showMedia(image, audio) {
+	const media = document.getElementById("media");
+	media.replaceChildren();
+	if (image) {
+		const img = el("img");
+		img.src = image;
+		media.appendChild(img);
+	}
+	if (audio) {
+		const player = el("audio");
+		player.src = audio;
+		player.controls = true;
+		media.appendChild(player);
+	}
+}
+
+function closeQuestion(key) {
+	answered.add(key);
+	document.getElementById("screen").classList.remove("open");
+	renderBoard();
+}
+
+function openQuestion(cat, question, key) {
+	const heading = cat.Name + " - $" + question.Value;
+	if (question.DailyDouble && players.length > 0) {
+		showScreen(heading, "Daily Double!");
+		const round = board.Rounds[roundIdx];
+		const who = el("select");
+		players.forEach(function (p, i) {
+			const option = el("option", p.name);
+			option.value = i;
+			who.appendChild(option);
+		});
+		const wager = el("input");
+		wager.type = "number";
+		wager.min = board.MinWager;
+		wager.placeholder = "Wager";
+		setControls(who, wager, button("Wager", function () {
+			const p = players[who.value];
+			const limit = Math.max(p.score, round.MaxValue, board.MinWager);
+			const amount = parseInt(wager.value, 10);
+			if (isNaN(amount) || amount < board.MinWager || amount > limit) {
+				alert("Wagers must be between " + board.MinWager + " and " + limit);
+				return;
+			}
+			showPrompt(heading, question, key, [p], amount);
+		}));
+		return;
+	}
+	showPrompt(heading, question, key, players, question.Value);
+}
+
+function showPrompt(heading, question, key, scorers, value) {
+	showScreen(heading, question.Prompt);
+	showMedia(question.PromptImage, question.PromptAudio);
+	setControls(button("Reveal Answer", function () {
+		document.getElementById("answer").textContent = question.Answer;
+		showMedia(question.AnswerImage, question.AnswerAudio);
+		const controls = [];
+		scorers.forEach(function (p) {
+			controls.push(button("✓ " + p.name, function () {
+				p.score += value;
+				renderScores();
+				closeQuestion(key);
+			}));
+			controls.push(button("✗ " + p.name, function () {
+				p.score -= value;
+				renderScores();
+			}));
+		});
+		controls.push(button("Back to Board", function () { closeQuestion(key); }));
+		setControls.apply(null, controls);
+	}));
+}
+
+// Final Jeopardy
+
+function openFinal() {
+	const final = board.Final;
+	showScreen("Final Jeopardy", final.Category);
+	setControls(button("Show Clue", function () {
+		showScreen("Final Jeopardy - " + final.Category, final.Prompt);
+		setControls(button("Reveal Answer", function () {
+			document.getElementById("answer").textContent = final.Answer;
+			const rows = players.map(function (p) {
+				const row = el("div", undefined, "row");
+				const wager = el("input");
+				wager.type = "number";
+				wager.min = 0;
+				wager.placeholder = "Wager";
+				const score = function (sign) {
+					return function () {
+						const amount = parseInt(wager.value, 10);
+						if (isNaN(amount) || amount < 0 || amount > Math.max(p.score, 0)) {
+							alert(p.name + " can wager between 0 and " + Math.max(p.score, 0));
+							return;
+						}
+						p.score += sign * amount;
+						renderScores();
+						row.replaceChildren(el("span", p.name + ": $" + p.score));
+					};
+				};
+				row.append(el("span", p.name), wager,
+					button("✓", score(1)), button("✗", score(-1)));
+				return row;
+			});
+			setControls.apply(null, rows);
+		}));
+	}));
+}
+
+renderScores();
+renderBoard();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,158 @@
+//========================================================================
+// sheet.go
+//========================================================================
+// Exporting a printable answer sheet for the host, as a self-contained
+// HTML page (which can be printed, or saved as a PDF, from a browser)
+//
+// Author: Aidan McNay
+// Date: June 26th, 2024
+
+package transfer
+
+import (
+	"html/template"
+	"io"
+	"jeopardy/logic"
+)
+
+var SheetExporter = &Exporter{
+	Name:        "sheet",
+	Extension:   ".html",
+	Description: "Printable Answer Sheet",
+	Options: []Option{
+		{"daily-doubles", "Mark Daily Doubles", true},
+		{"players", "Include the players", true},
+	},
+	Write: writeSheet,
+}
+
+//------------------------------------------------------------------------
+// Laying out the Sheet
+//------------------------------------------------------------------------
+// Each round is a table laid out like the on-screen grid, with a column
+// per category. Shorter categories leave their bottom cells empty
+
+type sheetCell struct {
+	Value       int
+	Question    *logic.Question
+	DailyDouble bool
+}
+
+type sheetRound struct {
+	Name       string
+	Categories [](*logic.Category)
+	Rows       [][](*sheetCell)
+}
+
+type sheet struct {
+	Name    string
+	Rounds  []sheetRound
+	Final   *logic.Category
+	Players [](*logic.Player)
+}
+
+func layoutRound(round *logic.Round, dailyDoubles bool) sheetRound {
+	layout := sheetRound{round.Name, round.Categories, nil}
+	// The round's height includes the row of category names
+	for row := 0; row < round.Height()-1; row++ {
+		var cells [](*sheetCell) = nil
+		for _, category := range round.Categories {
+			if row >= len(category.Questions) {
+				cells = append(cells, nil)
+				continue
+			}
+			q := category.Questions[row]
+			cells = append(cells,
+				&sheetCell{round.Value(q), q, dailyDoubles && q.DailyDouble})
+		}
+		layout.Rows = append(layout.Rows, cells)
+	}
+	return layout
+}
+
+func writeSheet(w io.Writer, board *logic.Board, options Options) error {
+	s := sheet{Name: board.Name}
+	for _, round := range board.Rounds {
+		s.Rounds = append(s.Rounds, layoutRound(round, options["daily-doubles"]))
+	}
+	if board.FinalQuestion() != nil {
+		s.Final = board.Final
+	}
+	if options["players"] {
+		s.Players = board.Players
+	}
+	return sheetTemplate.Execute(w, s)
+}
+
+//------------------------------------------------------------------------
+// Define the Template
+//------------------------------------------------------------------------
+
+var sheetTemplate = template.Must(template.New("sheet").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Name}} - Answer Sheet</title>
+<style>
+	@page { size: landscape; margin: 1cm; }
+	body { font-family: sans-serif; font-size: 10pt; margin: 0; }
+	h1 { font-size: 16pt; margin: 0 0 0.5em; }
+	h2 { font-size: 13pt; margin: 1em 0 0.5em; }
+	section + section { break-before: page; }
+	table { border-collapse: collapse; width: 100%; table-layout: fixed; }
+	th, td { border: 1px solid #000; padding: 4px; vertical-align: top; }
+	th { background: #ddd; }
+	.value { font-weight: bold; }
+	.dd { float: right; font-weight: bold; }
+	.prompt { margin: 2px 0; white-space: pre-wrap; }
+	.answer { font-style: italic; white-space: pre-wrap; }
+	.players td, .players th { width: auto; }
+	@media print { th { -webkit-print-color-adjust: exact; print-color-adjust: exact; } }
+</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+{{- range .Rounds}}
+<section>
+<h2>{{.Name}}</h2>
+<table>
+<tr>{{range .Categories}}<th>{{.Name}}</th>{{end}}</tr>
+{{- range .Rows}}
+<tr>
+{{- range .}}
+{{- if .}}
+<td><div class="value">${{.Value}}{{if .DailyDouble}}<span class="dd">Daily Double</span>{{end}}</div>
+<div class="prompt">{{.Question.Prompt}}</div>
+<div class="answer">{{.Question.Answer}}</div></td>
+{{- else}}
+<td></td>
+{{- end}}
+{{- end}}
+</tr>
+{{- end}}
+</table>
+</section>
+{{- end}}
+{{- if or .Final .Players}}
+<section>
+{{- with .Final}}
+<h2>Final Jeopardy: {{.Name}}</h2>
+{{- range .Questions}}
+<div class="prompt">{{.Prompt}}</div>
+<div class="answer">{{.Answer}}</div>
+{{- end}}
+{{- end}}
+{{- if .Players}}
+<h2>Players</h2>
+<table class="players">
+<tr><th>Name</th><th>Buzzer Key</th><th>Score</th></tr>
+{{- range .Players}}
+<tr><td>{{.GetName}}</td><td>{{.GetKey}}</td><td>{{.GetScore}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
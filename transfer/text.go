@@ -0,0 +1,88 @@
+//========================================================================
+// text.go
+//========================================================================
+// Exporting a board as plain text
+//
+// Author: Aidan McNay
+// Date: June 22nd, 2024
+
+package transfer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"jeopardy/logic"
+	"strings"
+)
+
+var TextExporter = &Exporter{
+	Name:        "text",
+	Extension:   ".txt",
+	Description: "Plain Text",
+	Write:       writeText,
+}
+
+//------------------------------------------------------------------------
+// Helper Functions
+//------------------------------------------------------------------------
+
+func underline(w *bufio.Writer, title string, line string) {
+	fmt.Fprintln(w, title)
+	fmt.Fprintln(w, strings.Repeat(line, len([]rune(title))))
+	fmt.Fprintln(w)
+}
+
+func writeTextQuestion(w *bufio.Writer, value string, q *logic.Question) {
+	fmt.Fprintf(w, "  %v", value)
+	if q.DailyDouble {
+		fmt.Fprint(w, " (Daily Double)")
+	}
+	if q.TimeLimit > 0 {
+		fmt.Fprintf(w, " (%vs)", q.TimeLimit)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "    Prompt: %v\n", q.Prompt)
+	fmt.Fprintf(w, "    Answer: %v\n", q.Answer)
+}
+
+//------------------------------------------------------------------------
+// writeText
+//------------------------------------------------------------------------
+// Lists every round, category and question in the order they appear on
+// screen, followed by Final Jeopardy
+
+func writeText(out io.Writer, board *logic.Board, _ Options) error {
+	w := bufio.NewWriter(out)
+	underline(w, board.Name, "=")
+
+	for _, round := range board.Rounds {
+		title := round.Name
+		if round.GetMultiplier() != 1 {
+			title += fmt.Sprintf(" (x%v)", round.GetMultiplier())
+		}
+		underline(w, title, "-")
+		for _, category := range round.Categories {
+			fmt.Fprintln(w, category.Name)
+			for _, q := range category.Questions {
+				writeTextQuestion(w, fmt.Sprintf("$%v", round.Value(q)), q)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
+	if final := board.FinalQuestion(); final != nil {
+		underline(w, "Final Jeopardy", "-")
+		fmt.Fprintln(w, board.Final.Name)
+		writeTextQuestion(w, "Wager", final)
+		fmt.Fprintln(w)
+	}
+
+	if len(board.Players) > 0 {
+		underline(w, "Players", "-")
+		for _, p := range board.Players {
+			fmt.Fprintf(w, "  %v: %v\n", p.GetName(), p.GetScore())
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,73 @@
+//========================================================================
+// transfer.go
+//========================================================================
+// Moving boards to and from formats other than our own
+//
+// Author: Aidan McNay
+// Date: June 22nd, 2024
+
+package transfer
+
+import (
+	"fmt"
+	"io"
+	"jeopardy/logic"
+	"strings"
+)
+
+//------------------------------------------------------------------------
+// Define an Exporter
+//------------------------------------------------------------------------
+// An exporter writes a board in another format, such as for people who
+// don't have the app. Some formats have options, which can be turned on
+// or off when exporting
+
+type Option struct {
+	Name        string
+	Description string
+	Default     bool
+}
+
+type Options map[string]bool
+
+type Exporter struct {
+	Name        string
+	Extension   string
+	Description string
+	Options     []Option
+	Write       func(w io.Writer, board *logic.Board, options Options) error
+}
+
+// Returns the exporter's options, all set to their defaults
+
+func (e *Exporter) Defaults() Options {
+	options := Options{}
+	for _, v := range e.Options {
+		options[v.Name] = v.Default
+	}
+	return options
+}
+
+//------------------------------------------------------------------------
+// Exporters
+//------------------------------------------------------------------------
+// Every format a board can be exported to, in the order they're offered
+
+var Exporters = [](*Exporter){
+	TextExporter,
+	CSVExporter,
+	SheetExporter,
+	PlayableExporter,
+}
+
+func FindExporter(name string) (*Exporter, error) {
+	var names []string = nil
+	for _, v := range Exporters {
+		if v.Name == name {
+			return v, nil
+		}
+		names = append(names, v.Name)
+	}
+	return nil, fmt.Errorf("unknown format %q (expected one of %v)",
+		name, strings.Join(names, ", "))
+}